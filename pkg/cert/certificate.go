@@ -17,7 +17,8 @@
 package cert
 
 import (
-	"crypto/rsa"
+	"bytes"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
@@ -26,7 +27,6 @@ import (
 
 	"k8s.io/client-go/util/cert"
 	"k8s.io/client-go/util/keyutil"
-	"k8s.io/kubernetes/cmd/kubeadm/app/util/pkiutil"
 )
 
 type info struct {
@@ -52,6 +52,38 @@ func (this *info) CAKey() []byte {
 	return this.cakey
 }
 
+func (this *info) Certificate() *x509.Certificate {
+	certs, err := parseCerts(this.cert)
+	if err != nil {
+		return nil
+	}
+	return certs[0]
+}
+
+func (this *info) CACertificates() []*x509.Certificate {
+	certs, err := parseCerts(this.cacert)
+	if err != nil {
+		return nil
+	}
+	return certs
+}
+
+func (this *info) NotAfter() time.Time {
+	c := this.Certificate()
+	if c == nil {
+		return time.Time{}
+	}
+	return c.NotAfter
+}
+
+func (this *info) CANotAfter() time.Time {
+	certs := this.CACertificates()
+	if len(certs) == 0 {
+		return time.Time{}
+	}
+	return certs[0].NotAfter
+}
+
 func NewCertInfo(cert []byte, key []byte, cacert []byte, cakey []byte) CertificateInfo {
 	return &info{
 		cert:   cert,
@@ -61,142 +93,187 @@ func NewCertInfo(cert []byte, key []byte, cacert []byte, cakey []byte) Certifica
 	}
 }
 
-func newPrivateKey() (*rsa.PrivateKey, error) {
-	signer, err := pkiutil.NewPrivateKey()
-	if err != nil {
-		return nil, err
+func parseCerts(data []byte) ([]*x509.Certificate, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no certificate")
 	}
-	key, ok := signer.(*rsa.PrivateKey)
-	if !ok {
-		return nil, fmt.Errorf("not a private key: %t", key)
-	}
-	return key, nil
+	return cert.ParseCertsPEM(data)
 }
 
-// EncodePrivateKeyPEM returns PEM-encoded private key data
-func encodePrivateKeyPEM(key *rsa.PrivateKey) []byte {
-	block := pem.Block{
-		Type:  pkiutil.RSAPrivateKeyBlockType,
-		Bytes: x509.MarshalPKCS1PrivateKey(key),
+func encodeCertsPEM(certs ...*x509.Certificate) []byte {
+	buf := &bytes.Buffer{}
+	for _, c := range certs {
+		pem.Encode(buf, &pem.Block{Type: cert.CertificateBlockType, Bytes: c.Raw})
 	}
-	return pem.EncodeToMemory(&block)
+	return buf.Bytes()
 }
 
+// UpdateCertificate checks a certificate for a single DNS name to be valid
+// for at least the given duration and creates a new one (and if required
+// a new RSA based CA), otherwise.
 func UpdateCertificate(old CertificateInfo, commonname, dnsname string, duration time.Duration) (CertificateInfo, error) {
-	new := &info{}
-	if old != nil {
-		new.cert = old.Cert()
-		new.key = old.Key()
-		new.cacert = old.CACert()
-		new.cakey = old.CAKey()
+	cfg := &Config{
+		CommonName:   "client:" + commonname,
+		CACommonName: "webhook-cert-ca:" + commonname,
+	}
+	if dnsname != "" {
+		cfg.DNSNames = []string{dnsname}
 	}
+	return UpdateCertificateFor(old, cfg, duration)
+}
 
-	var caKey *rsa.PrivateKey
-	var caCert *x509.Certificate
-	var newKey *rsa.PrivateKey
-	var newCert *x509.Certificate
-	var err error
-	var ok bool
-
-	if !IsValid(new, dnsname, duration) {
-		fmt.Printf("not valid\n")
-		if new.cacert != nil {
-			fmt.Printf("cacert found\n")
-			ok = Valid(new.cakey, new.cacert, new.cacert, "", 5*time.Hour*24)
-			if ok {
-				fmt.Printf("cacert not valid\n")
-				k, err := keyutil.ParsePrivateKeyPEM(new.cakey)
-				if err != nil {
-					ok = false
-				} else {
-					caKey, ok = k.(*rsa.PrivateKey)
-				}
-				certs, err := cert.ParseCertsPEM(new.cacert)
-				if err != nil {
-					ok = false
-				} else {
-					caCert = certs[0]
-				}
-			}
+// UpdateCertificateFor checks a certificate to match the given config and
+// to be valid for at least the given duration. Otherwise a new certificate
+// is created. If the CA expires within this duration, it is renewed, also.
+// The former CA is kept in the CA bundle during the grace period of the
+// config, to accept certificates still signed by the former CA. Former CAs
+// are removed from the bundle after the grace period, even if the certificate
+// is still valid.
+func UpdateCertificateFor(old CertificateInfo, cfg *Config, duration time.Duration) (CertificateInfo, error) {
+	cfg = cfg.withDefaults()
+	now := time.Now()
+	if old != nil && isValidFor(old, cfg, duration) {
+		cas := old.CACertificates()
+		bundle := caBundle(cas, cfg.CAGracePeriod, now)
+		if len(bundle) == len(cas) {
+			return old, nil
 		}
-		if new.cacert == nil || !ok {
-			fmt.Printf("generate cacert\n")
+		pruned := NewCertInfo(old.Cert(), old.Key(), encodeCertsPEM(bundle...), old.CAKey())
+		if isValidFor(pruned, cfg, duration) {
+			return pruned, nil
+		}
+	}
 
-			caKey, err = newPrivateKey()
-			if err != nil {
-				return nil, fmt.Errorf("failed to create the CA key pair: %s", err)
-			}
-			new.cakey = encodePrivateKeyPEM(caKey)
-			caCert, err = cert.NewSelfSignedCACert(cert.Config{CommonName: "webhook-cert-ca:" + commonname}, caKey)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create the CA cert: %s", err)
+	new := &info{}
+	var cas []*x509.Certificate
+	var caKey crypto.Signer
+
+	if old != nil && len(old.CAKey()) > 0 {
+		cas, _ = parseCerts(old.CACert())
+		if len(cas) > 0 && now.Add(duration).Before(cas[0].NotAfter) && matchesKey(cas[0], old.CAKey()) && matchesKeyType(cas[0], cfg) {
+			k, err := keyutil.ParsePrivateKeyPEM(old.CAKey())
+			if err == nil {
+				caKey, _ = k.(crypto.Signer)
 			}
-			new.cacert = pkiutil.EncodeCertPEM(caCert)
 		}
+	}
 
-		fmt.Printf("generate key\n")
-		newKey, err = newPrivateKey()
+	if caKey != nil {
+		new.cakey = old.CAKey()
+	} else {
+		var err error
+		var caCert *x509.Certificate
+		caKey, new.cakey, err = newPrivateKey(cfg)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create the server key pair: %s", err)
+			return nil, fmt.Errorf("failed to create the CA key pair: %s", err)
 		}
-		new.key = encodePrivateKeyPEM(newKey)
-		fmt.Printf("generate cert\n")
-		newCert, err = pkiutil.NewSignedCert(
-			&cert.Config{
-				CommonName: "client:" + commonname,
-				AltNames: cert.AltNames{
-					DNSNames: []string{dnsname},
-				},
-				Usages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
-			},
-			newKey, caCert, caKey)
+		caCert, err = newCACert(cfg, caKey, now)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create the server cert: %s", err)
+			return nil, fmt.Errorf("failed to create the CA cert: %s", err)
+		}
+		cas = append([]*x509.Certificate{caCert}, cas...)
+	}
+	new.cacert = encodeCertsPEM(caBundle(cas, cfg.CAGracePeriod, now)...)
+
+	key, keyData, err := newPrivateKey(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the server key pair: %s", err)
+	}
+	new.key = keyData
+	c, err := newSignedCert(cfg, key, cas[0], caKey, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the server cert: %s", err)
+	}
+	new.cert = encodeCertsPEM(c)
+	return new, nil
+}
+
+// caBundle provides the actual CA followed by all former CAs, which
+// are still valid and were replaced within the grace period.
+func caBundle(cas []*x509.Certificate, grace time.Duration, now time.Time) []*x509.Certificate {
+	bundle := []*x509.Certificate{cas[0]}
+	if now.After(cas[0].NotBefore.Add(grace)) {
+		return bundle
+	}
+	for _, c := range cas[1:] {
+		if now.Before(c.NotAfter) {
+			bundle = append(bundle, c)
 		}
-		new.cert = pkiutil.EncodeCertPEM(newCert)
-		return new, nil
 	}
-	return old, nil
+	return bundle
 }
 
 func IsValid(info CertificateInfo, dnsname string, duration time.Duration) bool {
-	if info.Cert() == nil || info.Key() == nil {
-		fmt.Printf("cert or key not set\n")
+	cfg := &Config{}
+	if dnsname != "" {
+		cfg.DNSNames = []string{dnsname}
+	}
+	return isValid(info, cfg, duration)
+}
+
+// IsValidFor checks a certificate to be valid for at least the given duration
+// for all the subject alternative names of the config and to use the
+// configured key type and size. The CA is expected to be valid for the
+// duration, also. A CA bundle still containing former CAs after the grace
+// period is not valid anymore.
+func IsValidFor(info CertificateInfo, cfg *Config, duration time.Duration) bool {
+	cfg = cfg.withDefaults()
+	if !isValidFor(info, cfg, duration) {
+		return false
+	}
+	cas := info.CACertificates()
+	return len(caBundle(cas, cfg.CAGracePeriod, time.Now())) == len(cas)
+}
+
+func isValidFor(info CertificateInfo, cfg *Config, duration time.Duration) bool {
+	if !isValid(info, cfg, duration) {
 		return false
 	}
-	if info.CACert() == nil {
-		fmt.Printf("cacert not set\n")
+	cas := info.CACertificates()
+	if len(cas) == 0 || !time.Now().Add(duration).Before(cas[0].NotAfter) || !matchesKey(cas[0], info.CAKey()) {
 		return false
 	}
-	return Valid(info.Key(), info.Cert(), info.CACert(), dnsname, duration)
+	return matchesKeyType(info.Certificate(), cfg) && matchesKeyType(cas[0], cfg)
+}
+
+func isValid(info CertificateInfo, cfg *Config, duration time.Duration) bool {
+	if info.Cert() == nil || info.Key() == nil || info.CACert() == nil {
+		return false
+	}
+	if !Valid(info.Key(), info.Cert(), info.CACert(), "", duration) {
+		return false
+	}
+	c := info.Certificate()
+	for _, n := range cfg.DNSNames {
+		if c.VerifyHostname(n) != nil {
+			return false
+		}
+	}
+	for _, ip := range cfg.IPAddresses {
+		if c.VerifyHostname(ip.String()) != nil {
+			return false
+		}
+	}
+	return true
 }
 
 func Valid(key []byte, cert []byte, cacert []byte, dnsname string, duration time.Duration) bool {
 
 	if len(cert) == 0 || len(key) == 0 || len(cacert) == 0 {
-		fmt.Printf("something empty\n")
 		return false
 	}
 
 	_, err := tls.X509KeyPair(cert, key)
 	if err != nil {
-		fmt.Printf("key does not match cert\n")
 		return false
 	}
 
 	pool := x509.NewCertPool()
 	if !pool.AppendCertsFromPEM(cacert) {
-		fmt.Printf("cannot create pool\n")
-		return false
-	}
-	block, _ := pem.Decode([]byte(cert))
-	if block == nil {
-		fmt.Printf("cannot decode cert\n")
 		return false
 	}
-	c, err := x509.ParseCertificate(block.Bytes)
+	certs, err := parseCerts(cert)
 	if err != nil {
-		fmt.Printf("cannot parse cert\n")
 		return false
 	}
 	ops := x509.VerifyOptions{
@@ -204,7 +281,11 @@ func Valid(key []byte, cert []byte, cacert []byte, dnsname string, duration time
 		Roots:       pool,
 		CurrentTime: time.Now().Add(duration),
 	}
-	_, err = c.Verify(ops)
-	fmt.Printf("val: %s\n", err)
+	_, err = certs[0].Verify(ops)
+	return err == nil
+}
+
+func matchesKey(c *x509.Certificate, key []byte) bool {
+	_, err := tls.X509KeyPair(encodeCertsPEM(c), key)
 	return err == nil
 }
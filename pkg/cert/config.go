@@ -0,0 +1,201 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package cert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"k8s.io/client-go/util/keyutil"
+)
+
+type KeyType string
+
+const RSA = KeyType("rsa")
+const ECDSA = KeyType("ecdsa")
+
+const DEFAULT_RSA_KEY_SIZE = 2048
+const DEFAULT_ECDSA_KEY_SIZE = 256
+
+const DEFAULT_CA_VALIDITY = 10 * 365 * 24 * time.Hour
+const DEFAULT_VALIDITY = 365 * 24 * time.Hour
+const DEFAULT_CA_GRACE_PERIOD = 7 * 24 * time.Hour
+
+// Config describes the certificate and CA to generate.
+type Config struct {
+	CommonName string
+	// CACommonName is the common name of the generated CA (default: <CommonName>-ca)
+	CACommonName string
+	DNSNames     []string
+	IPAddresses  []net.IP
+
+	// KeyType is the type of the generated keys (default: RSA)
+	KeyType KeyType
+	// KeySize is the number of bits for RSA keys (default: 2048) or
+	// the curve size for ECDSA keys (256, 384 or 521, default: 256)
+	KeySize int
+
+	// CAValidity is the lifetime of a generated CA (default: 10 years)
+	CAValidity time.Duration
+	// Validity is the lifetime of a generated certificate (default: 1 year).
+	// It is limited by the expiry of the CA.
+	Validity time.Duration
+	// CAGracePeriod is the period a replaced CA is kept in the
+	// CA bundle (default: 7 days).
+	CAGracePeriod time.Duration
+}
+
+func (this *Config) withDefaults() *Config {
+	cfg := *this
+	if cfg.KeyType == "" {
+		cfg.KeyType = RSA
+	}
+	if cfg.KeySize <= 0 {
+		if cfg.KeyType == ECDSA {
+			cfg.KeySize = DEFAULT_ECDSA_KEY_SIZE
+		} else {
+			cfg.KeySize = DEFAULT_RSA_KEY_SIZE
+		}
+	}
+	if cfg.CACommonName == "" {
+		cfg.CACommonName = cfg.CommonName + "-ca"
+	}
+	if cfg.CAValidity <= 0 {
+		cfg.CAValidity = DEFAULT_CA_VALIDITY
+	}
+	if cfg.Validity <= 0 {
+		cfg.Validity = DEFAULT_VALIDITY
+	}
+	if cfg.CAGracePeriod <= 0 {
+		cfg.CAGracePeriod = DEFAULT_CA_GRACE_PERIOD
+	}
+	return &cfg
+}
+
+func curve(size int) (elliptic.Curve, error) {
+	switch size {
+	case 256:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	}
+	return nil, fmt.Errorf("unsupported ECDSA key size %d", size)
+}
+
+// newPrivateKey generates a key according to the config and
+// returns it together with its PEM encoding.
+func newPrivateKey(cfg *Config) (crypto.Signer, []byte, error) {
+	switch cfg.KeyType {
+	case RSA:
+		key, err := rsa.GenerateKey(rand.Reader, cfg.KeySize)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, pem.EncodeToMemory(&pem.Block{Type: keyutil.RSAPrivateKeyBlockType, Bytes: x509.MarshalPKCS1PrivateKey(key)}), nil
+	case ECDSA:
+		c, err := curve(cfg.KeySize)
+		if err != nil {
+			return nil, nil, err
+		}
+		key, err := ecdsa.GenerateKey(c, rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, pem.EncodeToMemory(&pem.Block{Type: keyutil.ECPrivateKeyBlockType, Bytes: data}), nil
+	}
+	return nil, nil, fmt.Errorf("unsupported key type %q", cfg.KeyType)
+}
+
+func matchesKeyType(c *x509.Certificate, cfg *Config) bool {
+	if c == nil {
+		return false
+	}
+	switch k := c.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return cfg.KeyType == RSA && k.N.BitLen() == cfg.KeySize
+	case *ecdsa.PublicKey:
+		return cfg.KeyType == ECDSA && k.Curve.Params().BitSize == cfg.KeySize
+	}
+	return false
+}
+
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func newCACert(cfg *Config, key crypto.Signer, now time.Time) (*x509.Certificate, error) {
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cfg.CACommonName},
+		NotBefore:             now.UTC(),
+		NotAfter:              now.Add(cfg.CAValidity).UTC(),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	data, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(data)
+}
+
+func newSignedCert(cfg *Config, key crypto.Signer, ca *x509.Certificate, caKey crypto.Signer, now time.Time) (*x509.Certificate, error) {
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, err
+	}
+	notAfter := now.Add(cfg.Validity)
+	if notAfter.After(ca.NotAfter) {
+		notAfter = ca.NotAfter
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cfg.CommonName},
+		DNSNames:     cfg.DNSNames,
+		IPAddresses:  cfg.IPAddresses,
+		NotBefore:    now.UTC(),
+		NotAfter:     notAfter.UTC(),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	data, err := x509.CreateCertificate(rand.Reader, tmpl, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(data)
+}
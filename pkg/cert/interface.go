@@ -16,9 +16,26 @@
 
 package cert
 
+import (
+	"crypto/x509"
+	"time"
+)
+
 type CertificateInfo interface {
 	Cert() []byte
 	Key() []byte
+	// CACert is the CA bundle. The first certificate is the
+	// actual CA, followed by former CAs during their grace period.
 	CACert() []byte
 	CAKey() []byte
+
+	// Certificate is the parsed certificate (nil if not parsable)
+	Certificate() *x509.Certificate
+	// CACertificates are the parsed certificates of the CA bundle
+	CACertificates() []*x509.Certificate
+
+	// NotAfter is the expiry of the certificate (zero if not parsable)
+	NotAfter() time.Time
+	// CANotAfter is the expiry of the actual CA (zero if not parsable)
+	CANotAfter() time.Time
 }
@@ -0,0 +1,57 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package certmgmt
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/cert"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+type chainCertificateAccess []CertificateAccess
+
+var _ CertificateAccess = chainCertificateAccess{}
+
+// NewChain provides a certificate access reading from several sources.
+// The first source providing certificate data is used. Updates are
+// always written to the first source, so it should be the one used for
+// maintaining the certificate, while the others are used as fallback,
+// for example during a migration.
+func NewChain(accesses ...CertificateAccess) CertificateAccess {
+	return chainCertificateAccess(accesses)
+}
+
+func (this chainCertificateAccess) Get(logger logger.LogContext) (cert.CertificateInfo, error) {
+	for _, a := range this {
+		info, err := a.Get(logger)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			return info, nil
+		}
+	}
+	return nil, nil
+}
+
+func (this chainCertificateAccess) Set(logger logger.LogContext, cert cert.CertificateInfo) error {
+	if len(this) == 0 {
+		return fmt.Errorf("no certificate access configured in chain")
+	}
+	return this[0].Set(logger, cert)
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package certmgmt
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/cert"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/fieldpath"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var configMapDataField = fieldpath.RequiredField(&corev1.ConfigMap{}, ".Data")
+
+type splitCertificateAccess struct {
+	secret    *secretCertificateAccess
+	configmap resources.ObjectName
+}
+
+var _ CertificateAccess = &splitCertificateAccess{}
+
+// NewConfigMapSecret provides a certificate access publishing the CA
+// certificate in a config map, while the keys and the certificate
+// are kept in a secret. In dry-run mode the updates are kept in memory
+// (see NewDryRunAccess).
+func NewConfigMapSecret(cluster cluster.Interface, configmap, secret resources.ObjectName) CertificateAccess {
+	access := &splitCertificateAccess{
+		secret:    &secretCertificateAccess{cluster: cluster, name: secret},
+		configmap: configmap,
+	}
+	if cluster.ResourceContext().IsDryRun() {
+		return NewDryRunAccess(access)
+	}
+	return access
+}
+
+func (this *splitCertificateAccess) Get(logger logger.LogContext) (cert.CertificateInfo, error) {
+	info, err := this.secret.Get(logger)
+	if err != nil || info == nil {
+		return info, err
+	}
+	cm := &corev1.ConfigMap{}
+	_, err = this.secret.cluster.Resources().GetObjectInto(this.configmap, cm)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return cert.NewCertInfo(info.Cert(), info.Key(), nil, info.CAKey()), nil
+	}
+	return cert.NewCertInfo(info.Cert(), info.Key(), []byte(cm.Data[CACertName]), info.CAKey()), nil
+}
+
+func (this *splitCertificateAccess) Set(logger logger.LogContext, cert cert.CertificateInfo) error {
+	r, _ := this.secret.cluster.GetResource(schema.GroupKind{Group: corev1.GroupName, Kind: "ConfigMap"})
+	o := r.New(this.configmap)
+	data := map[string]string{CACertName: string(cert.CACert())}
+	mod, err := resources.CreateOrModify(o, func(mod *resources.ModificationState) error {
+		mod.Set(configMapDataField, data)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if mod {
+		logger.Infof("CA cert in config map %q is updated", this.configmap)
+	}
+	return this.secret.setData(logger, certInfoToSecretData(cert))
+}
+
+func certInfoToSecretData(cert cert.CertificateInfo) map[string][]byte {
+	data := certInfoToData(cert)
+	delete(data, CACertName)
+	return data
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package certmgmt
+
+import (
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/cert"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+type dryRunCertificateAccess struct {
+	access CertificateAccess
+	lock   sync.Mutex
+	info   cert.CertificateInfo
+}
+
+var _ CertificateAccess = &dryRunCertificateAccess{}
+
+// NewDryRunAccess wraps a certificate access whose updates are only
+// executed as dry-run. A certificate set is kept in memory and provided
+// instead of the unchanged certificate of the access, so that a renewer
+// does not generate a new certificate with every check.
+func NewDryRunAccess(access CertificateAccess) CertificateAccess {
+	return &dryRunCertificateAccess{access: access}
+}
+
+func (this *dryRunCertificateAccess) Get(logger logger.LogContext) (cert.CertificateInfo, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.info != nil {
+		return this.info, nil
+	}
+	return this.access.Get(logger)
+}
+
+func (this *dryRunCertificateAccess) Set(logger logger.LogContext, cert cert.CertificateInfo) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	err := this.access.Set(logger, cert)
+	if err == nil {
+		this.info = cert
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package certmgmt
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/gardener/controller-manager-library/pkg/cert"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+type fileCertificateAccess struct {
+	dir string
+}
+
+var _ CertificateAccess = &fileCertificateAccess{}
+
+// NewFile provides a certificate access storing the certificate
+// data as PEM files in the given directory. The files are named
+// like the keys used for secrets.
+func NewFile(dir string) CertificateAccess {
+	return &fileCertificateAccess{
+		dir: dir,
+	}
+}
+
+func (this *fileCertificateAccess) Get(logger logger.LogContext) (cert.CertificateInfo, error) {
+	data := map[string][]byte{}
+	for _, n := range []string{CAKeyName, CACertName, KeyName, CertName} {
+		d, err := ioutil.ReadFile(filepath.Join(this.dir, n))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		data[n] = d
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return dataToCertInfo(data), nil
+}
+
+func (this *fileCertificateAccess) Set(logger logger.LogContext, cert cert.CertificateInfo) error {
+	err := os.MkdirAll(this.dir, 0700)
+	if err != nil {
+		return err
+	}
+	for n, d := range certInfoToData(cert) {
+		err := ioutil.WriteFile(filepath.Join(this.dir, n), d, 0600)
+		if err != nil {
+			return fmt.Errorf("cannot write %q: %s", n, err)
+		}
+	}
+	logger.Infof("certs in directory %q are updated", this.dir)
+	return nil
+}
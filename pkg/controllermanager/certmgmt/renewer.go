@@ -0,0 +1,191 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package certmgmt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/cert"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/server/healthz"
+	"github.com/gardener/controller-manager-library/pkg/server/metrics"
+)
+
+const DEFAULT_RENEWAL_THRESHOLD = 7 * 24 * time.Hour
+const DEFAULT_RENEWAL_PERIOD = 1 * time.Hour
+
+var expiry = metrics.NewGaugeVec("certificate_expiry_timestamp_seconds",
+	"expiry of maintained certificates (unix time)", "name", "type")
+
+// CertificateHandler is notified about new certificates.
+// If it fails, it is called again with the next check.
+type CertificateHandler func(info cert.CertificateInfo) error
+
+// Renewer periodically checks the certificate of a CertificateAccess
+// and renews it before it expires. Subscribers are notified whenever
+// the certificate changes, either by a renewal or by an update of the
+// certificate access done by someone else.
+// The renewer provides a health check and the expiry of the certificate
+// and its CA as metric (certificate_expiry_timestamp_seconds).
+type Renewer struct {
+	logger.LogContext
+	name      string
+	access    CertificateAccess
+	config    *cert.Config
+	threshold time.Duration
+	period    time.Duration
+
+	lock        sync.Mutex
+	info        cert.CertificateInfo
+	subscribers []*subscriber
+}
+
+type subscriber struct {
+	handler CertificateHandler
+	pending bool
+}
+
+// NewRenewer creates a renewer for the given certificate access.
+// A zero threshold or period is replaced by its default.
+func NewRenewer(logger logger.LogContext, name string, access CertificateAccess, config *cert.Config, threshold, period time.Duration) *Renewer {
+	if threshold <= 0 {
+		threshold = DEFAULT_RENEWAL_THRESHOLD
+	}
+	if period <= 0 {
+		period = DEFAULT_RENEWAL_PERIOD
+	}
+	return &Renewer{
+		LogContext: logger.NewContext("certificate", name),
+		name:       name,
+		access:     access,
+		config:     config,
+		threshold:  threshold,
+		period:     period,
+	}
+}
+
+func (this *Renewer) GetName() string {
+	return this.name
+}
+
+func (this *Renewer) GetCertificateInfo() cert.CertificateInfo {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.info
+}
+
+// Subscribe adds a handler notified about certificate changes. If there
+// is already a certificate, the handler is notified with the next check.
+func (this *Renewer) Subscribe(h CertificateHandler) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.subscribers = append(this.subscribers, &subscriber{handler: h, pending: this.info != nil})
+}
+
+// Check reads the certificate from the certificate access, renews it if
+// required and notifies the subscribers if the certificate has changed.
+func (this *Renewer) Check() (cert.CertificateInfo, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	info, err := this.access.Get(this)
+	if err != nil {
+		return nil, fmt.Errorf("error reading from certificate access: %s", err)
+	}
+	if info == nil || !cert.IsValidFor(info, this.config, this.threshold) {
+		this.Infof("renewing certificate")
+		info, err = cert.UpdateCertificateFor(info, this.config, this.threshold)
+		if err != nil {
+			return nil, fmt.Errorf("cert update failed: %s", err)
+		}
+		err = this.access.Set(this, info)
+		if err != nil {
+			return nil, fmt.Errorf("certificate update failed: %s", err)
+		}
+	}
+
+	expiry.Set(float64(info.NotAfter().Unix()), this.name, "cert")
+	expiry.Set(float64(info.CANotAfter().Unix()), this.name, "ca")
+
+	if this.info == nil || !bytes.Equal(this.info.Cert(), info.Cert()) || !bytes.Equal(this.info.CACert(), info.CACert()) {
+		if this.info != nil {
+			this.Infof("certificate changed (valid until %s)", info.NotAfter())
+		}
+		this.info = info
+		for _, s := range this.subscribers {
+			s.pending = true
+		}
+	}
+	for _, s := range this.subscribers {
+		if s.pending {
+			err := s.handler(info)
+			if err != nil {
+				this.Errorf("certificate notification failed: %s", err)
+			} else {
+				s.pending = false
+			}
+		}
+	}
+	return info, nil
+}
+
+func (this *Renewer) healthKey() string {
+	return "certificate:" + this.name
+}
+
+// Start does an initial check and continues checking the certificate
+// in the background until the context is done.
+func (this *Renewer) Start(ctx context.Context) error {
+	_, err := this.Check()
+	if err != nil {
+		return err
+	}
+	healthz.Start(this.healthKey(), this.period)
+	go func() {
+		defer healthz.End(this.healthKey())
+		ticker := time.NewTicker(this.period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				this.check()
+			}
+		}
+	}()
+	return nil
+}
+
+// check does a periodic check. The health check is only updated as long
+// as a valid certificate is available.
+func (this *Renewer) check() {
+	info, err := this.Check()
+	if err != nil {
+		this.Errorf("%s", err)
+		info = this.GetCertificateInfo()
+	}
+	if info != nil && time.Now().Before(info.NotAfter()) {
+		healthz.Tick(this.healthKey())
+	} else {
+		this.Errorf("no valid certificate")
+	}
+}
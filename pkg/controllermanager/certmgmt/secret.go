@@ -48,11 +48,17 @@ type secretCertificateAccess struct {
 
 var _ CertificateAccess = &secretCertificateAccess{}
 
+// NewSecret provides a certificate access for a secret. In dry-run mode
+// the updates of the secret are kept in memory (see NewDryRunAccess).
 func NewSecret(cluster cluster.Interface, name resources.ObjectName) CertificateAccess {
-	return &secretCertificateAccess{
+	access := &secretCertificateAccess{
 		cluster: cluster,
 		name:    name,
 	}
+	if cluster.ResourceContext().IsDryRun() {
+		return NewDryRunAccess(access)
+	}
+	return access
 }
 
 func (this *secretCertificateAccess) Get(logger logger.LogContext) (cert.CertificateInfo, error) {
@@ -68,10 +74,12 @@ func (this *secretCertificateAccess) Get(logger logger.LogContext) (cert.Certifi
 }
 
 func (this *secretCertificateAccess) Set(logger logger.LogContext, cert cert.CertificateInfo) error {
+	return this.setData(logger, certInfoToData(cert))
+}
 
-	r, _ := this.cluster.GetResource(schema.GroupKind{corev1.GroupName, "Secret"})
+func (this *secretCertificateAccess) setData(logger logger.LogContext, data map[string][]byte) error {
+	r, _ := this.cluster.GetResource(schema.GroupKind{Group: corev1.GroupName, Kind: "Secret"})
 	o := r.New(this.name)
-	data := certInfoToData(cert)
 	mod, err := resources.CreateOrModify(o, func(mod *resources.ModificationState) error {
 		mod.Set(dataField, data)
 		return nil
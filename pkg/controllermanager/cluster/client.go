@@ -0,0 +1,107 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/config"
+
+	restclient "k8s.io/client-go/rest"
+)
+
+// ClientSettings describes the tuning of the client access for a cluster.
+// Zero values keep the settings of the rest config.
+type ClientSettings struct {
+	QPS               float32
+	Burst             int
+	Timeout           time.Duration
+	UserAgent         string
+	ImpersonateUser   string
+	ImpersonateGroups []string
+}
+
+// Apply applies the settings to a rest config.
+func (this *ClientSettings) Apply(cfg *restclient.Config) {
+	if this.QPS > 0 {
+		cfg.QPS = this.QPS
+	}
+	if this.Burst > 0 {
+		cfg.Burst = this.Burst
+	}
+	if this.Timeout > 0 {
+		cfg.Timeout = this.Timeout
+	}
+	if this.UserAgent != "" {
+		cfg.UserAgent = this.UserAgent
+	}
+	if this.ImpersonateUser != "" {
+		cfg.Impersonate.UserName = this.ImpersonateUser
+	}
+	if len(this.ImpersonateGroups) > 0 {
+		cfg.Impersonate.Groups = this.ImpersonateGroups
+	}
+}
+
+func addClientSettingsOptions(cfg *config.Config, req Definition) {
+	opt, _ := cfg.AddFloatOption(req.ConfigOptionName() + SUBOPTION_QPS)
+	opt.Description = fmt.Sprintf("maximum queries per second to cluster %s", req.Name())
+
+	opt, _ = cfg.AddIntOption(req.ConfigOptionName() + SUBOPTION_BURST)
+	opt.Description = fmt.Sprintf("maximum burst of queries to cluster %s", req.Name())
+
+	opt, _ = cfg.AddDurationOption(req.ConfigOptionName() + SUBOPTION_TIMEOUT)
+	opt.Description = fmt.Sprintf("request timeout for cluster %s", req.Name())
+
+	opt, _ = cfg.AddStringOption(req.ConfigOptionName() + SUBOPTION_USER_AGENT)
+	opt.Description = fmt.Sprintf("user agent used for cluster %s", req.Name())
+
+	opt, _ = cfg.AddStringOption(req.ConfigOptionName() + SUBOPTION_IMPERSONATE_USER)
+	opt.Description = fmt.Sprintf("user to impersonate for cluster %s", req.Name())
+
+	opt, _ = cfg.AddStringArrayOption(req.ConfigOptionName() + SUBOPTION_IMPERSONATE_GROUPS)
+	opt.Description = fmt.Sprintf("group to impersonate for cluster %s", req.Name())
+}
+
+// GetClientSettings provides the client settings configured for a cluster.
+func GetClientSettings(cfg *config.Config, req Definition) *ClientSettings {
+	settings := &ClientSettings{}
+	name := req.ConfigOptionName()
+	if name == "" {
+		return settings
+	}
+	if opt := cfg.GetOption(name + SUBOPTION_QPS); opt != nil && opt.Changed() {
+		settings.QPS = float32(opt.FloatValue())
+	}
+	if opt := cfg.GetOption(name + SUBOPTION_BURST); opt != nil && opt.Changed() {
+		settings.Burst = opt.IntValue()
+	}
+	if opt := cfg.GetOption(name + SUBOPTION_TIMEOUT); opt != nil && opt.Changed() {
+		settings.Timeout = opt.DurationValue()
+	}
+	if opt := cfg.GetOption(name + SUBOPTION_USER_AGENT); opt != nil && opt.Changed() {
+		settings.UserAgent = opt.StringValue()
+	}
+	if opt := cfg.GetOption(name + SUBOPTION_IMPERSONATE_USER); opt != nil && opt.Changed() {
+		settings.ImpersonateUser = opt.StringValue()
+	}
+	if opt := cfg.GetOption(name + SUBOPTION_IMPERSONATE_GROUPS); opt != nil && opt.Changed() {
+		settings.ImpersonateGroups = opt.StringArray()
+	}
+	return settings
+}
@@ -36,8 +36,17 @@ import (
 
 const DEFAULT = "default"
 
+const DEFAULT_DISCOVERY_REFRESH_PERIOD = 10 * time.Minute
+
 const SUBOPTION_ID = ".id"
 const SUBOPTION_DISABLE_DEPLOY_CRDS = ".disable-deploy-crds"
+const SUBOPTION_CONTEXT = ".context"
+const SUBOPTION_QPS = ".qps"
+const SUBOPTION_BURST = ".burst"
+const SUBOPTION_TIMEOUT = ".timeout"
+const SUBOPTION_USER_AGENT = ".user-agent"
+const SUBOPTION_IMPERSONATE_USER = ".as"
+const SUBOPTION_IMPERSONATE_GROUPS = ".as-group"
 
 func Canonical(names []string) []string {
 	if names == nil {
@@ -73,6 +82,7 @@ type Interface interface {
 	ResourceContext() resources.ResourceContext
 	IsLocal() bool
 	Definition() Definition
+	Connectivity() Connectivity
 
 	resources.ClusterSource
 }
@@ -92,6 +102,8 @@ type _Cluster struct {
 	rctx       resources.ResourceContext
 	resources  resources.Resources
 	attributes map[interface{}]interface{}
+
+	connectivity *connectivity
 }
 
 var _ Interface = &_Cluster{}
@@ -112,6 +124,10 @@ func (this *_Cluster) Definition() Definition {
 	return this.definition
 }
 
+func (this *_Cluster) Connectivity() Connectivity {
+	return this.connectivity
+}
+
 func (this *_Cluster) GetId() string {
 	if this.id != "" {
 		return this.id
@@ -167,6 +183,29 @@ func (this *_Cluster) GetServerVersion() *semver.Version {
 	return this.rctx.GetServerVersion()
 }
 
+// startDiscoveryRefresh periodically refreshes the discovery information
+// of the cluster to get aware of resources added later on.
+func (this *_Cluster) startDiscoveryRefresh(logger logger.LogContext, period time.Duration) {
+	if period <= 0 {
+		period = DEFAULT_DISCOVERY_REFRESH_PERIOD
+	}
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-this.ctx.Done():
+				return
+			case <-ticker.C:
+				err := this.rctx.Refresh()
+				if err != nil {
+					logger.Warnf("discovery refresh for cluster %s failed: %s", this.name, err)
+				}
+			}
+		}
+	}()
+}
+
 func (this *_Cluster) setup(logger logger.LogContext) error {
 	rctx, err := resources.NewResourceContext(this.ctx, this, nil, 0*time.Second)
 	if err != nil {
@@ -177,30 +216,118 @@ func (this *_Cluster) setup(logger logger.LogContext) error {
 	return nil
 }
 
+// CreateCluster creates a cluster for a kubeconfig file. If no file is
+// given, the KUBECONFIG environment variable or the in-cluster
+// configuration is used.
 func CreateCluster(ctx context.Context, logger logger.LogContext, req Definition, id string, kubeconfig string) (Interface, error) {
+	return CreateClusterForContext(ctx, logger, req, id, kubeconfig, "")
+}
+
+// CreateClusterForContext creates a cluster for a dedicated context of a
+// kubeconfig file. If no context is given, the current context is used.
+func CreateClusterForContext(ctx context.Context, logger logger.LogContext, req Definition, id string, kubeconfig string, context string) (Interface, error) {
+	kubeConfig, local, err := loadKubeconfig(logger, req, id, kubeconfig, context)
+	if err != nil {
+		return nil, err
+	}
+	return createCluster(ctx, logger, req, id, kubeConfig, local, nil)
+}
+
+// CreateClusterForKubeconfigData creates a cluster for the given kubeconfig
+// content. If no context is given, the current context is used.
+func CreateClusterForKubeconfigData(ctx context.Context, logger logger.LogContext, req Definition, id string, data []byte, context string) (Interface, error) {
+	kubeConfig, err := loadKubeconfigData(logger, req, id, data, context)
+	if err != nil {
+		return nil, err
+	}
+	return createCluster(ctx, logger, req, id, kubeConfig, false, nil)
+}
+
+// CreateClusterForRestConfig creates a cluster for an in-memory rest config.
+// The config is copied, so later modifications are not propagated.
+func CreateClusterForRestConfig(ctx context.Context, logger logger.LogContext, req Definition, id string, kubeConfig *restclient.Config) (Interface, error) {
+	kubeConfig, err := copyRestConfig(logger, req, id, kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	return createCluster(ctx, logger, req, id, kubeConfig, false, nil)
+}
+
+func loadKubeconfig(logger logger.LogContext, req Definition, id string, kubeconfig string, context string) (*restclient.Config, bool, error) {
 	name := req.Name()
-	cluster := &_Cluster{name: name, attributes: map[interface{}]interface{}{}}
 
 	if kubeconfig == "" {
 		kubeconfig = os.Getenv("KUBECONFIG")
 	}
 
-	logger.Infof("using %q for cluster %q[%s]", kubeconfig, name, id)
-	kubeConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	var err error
+	var kubeConfig *restclient.Config
+	if context == "" {
+		logger.Infof("using %q for cluster %q[%s]", kubeconfig, name, id)
+		kubeConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		logger.Infof("using context %q of %q for cluster %q[%s]", context, kubeconfig, name, id)
+		rules := clientcmd.NewDefaultClientConfigLoadingRules()
+		rules.ExplicitPath = kubeconfig
+		kubeConfig, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: context}).ClientConfig()
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create cluster %q: %s", name, err)
+	}
+	return kubeConfig, kubeconfig == "" && context == "", nil
+}
+
+func loadKubeconfigData(logger logger.LogContext, req Definition, id string, data []byte, context string) (*restclient.Config, error) {
+	name := req.Name()
+
+	logger.Infof("using kubeconfig data (context %q) for cluster %q[%s]", context, name, id)
+	cfg, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster %q: invalid kubeconfig: %s", name, err)
+	}
+	kubeConfig, err := clientcmd.NewNonInteractiveClientConfig(*cfg, context, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cluster %q: %s", name, err)
 	}
+	return kubeConfig, nil
+}
+
+func copyRestConfig(logger logger.LogContext, req Definition, id string, kubeConfig *restclient.Config) (*restclient.Config, error) {
+	if kubeConfig == nil {
+		return nil, fmt.Errorf("failed to create cluster %q: no rest config", req.Name())
+	}
+	logger.Infof("using rest config for %q for cluster %q[%s]", kubeConfig.Host, req.Name(), id)
+	return restclient.CopyConfig(kubeConfig), nil
+}
+
+// createCluster creates and sets up a cluster object. The given config is
+// used for tuning the cluster monitoring, it may be nil.
+func createCluster(ctx context.Context, logger logger.LogContext, req Definition, id string, kubeConfig *restclient.Config, local bool, cfg *config.Config) (Interface, error) {
+	checkPeriod := time.Duration(0)
+	refreshPeriod := time.Duration(0)
+	if cfg != nil {
+		checkPeriod = cfg.ClusterCheckPeriod
+		refreshPeriod = cfg.DiscoveryRefreshPeriod
+	}
+	cluster := &_Cluster{name: req.Name(), attributes: map[interface{}]interface{}{}}
 
 	cluster.ctx = ctx
 	cluster.definition = req
 	cluster.id = id
 	cluster.kubeConfig = kubeConfig
-	cluster.local = kubeconfig == ""
+	cluster.local = local
+	cluster.connectivity = newConnectivity(logger, cluster, checkPeriod)
+	cluster.connectivity.wrap(kubeConfig)
 
-	err = cluster.setup(logger)
+	err := cluster.setup(logger)
+	if err != nil {
+		return nil, err
+	}
+	err = cluster.connectivity.start(ctx, kubeConfig)
 	if err != nil {
 		return nil, err
 	}
+	cluster.startDiscoveryRefresh(logger, refreshPeriod)
 
 	return cluster, nil
 }
@@ -220,6 +347,12 @@ type Clusters interface {
 	GetObject(key resources.ClusterObjectKey) (resources.Object, error)
 	GetCachedObject(key resources.ClusterObjectKey) (resources.Object, error)
 
+	// Extend provides a copy of the cluster set with an additional cluster
+	Extend(name string, cluster Interface, info ...interface{}) Clusters
+	// WithFieldManager provides a copy of the cluster set using views of
+	// the clusters with the given default field manager (see WithFieldManager)
+	WithFieldManager(fieldManager string) Clusters
+
 	String() string
 }
 
@@ -249,6 +382,9 @@ func (this *_Clusters) Add(name string, cluster Interface, info ...interface{})
 	} else {
 		this.infos[name] = name
 	}
+	if o := this.byid[cluster.GetId()]; o != nil && o != cluster {
+		logger.Warnf("clusters %q and %q use the same id %q", o.GetName(), cluster.GetName(), cluster.GetId())
+	}
 	this.clusters[name] = cluster
 	this.effective[cluster.GetName()] = cluster
 	set := this.mapped[cluster.GetName()]
@@ -260,6 +396,29 @@ func (this *_Clusters) Add(name string, cluster Interface, info ...interface{})
 	set.Add(name)
 }
 
+func (this *_Clusters) Extend(name string, cluster Interface, info ...interface{}) Clusters {
+	clusters := NewClusters()
+	for n, c := range this.clusters {
+		clusters.Add(n, c, this.infos[n])
+	}
+	clusters.Add(name, cluster, info...)
+	return clusters
+}
+
+func (this *_Clusters) WithFieldManager(fieldManager string) Clusters {
+	clusters := NewClusters()
+	views := map[Interface]Interface{}
+	for n, c := range this.clusters {
+		v := views[c]
+		if v == nil {
+			v = WithFieldManager(c, fieldManager)
+			views[c] = v
+		}
+		clusters.Add(n, v, this.infos[n])
+	}
+	return clusters
+}
+
 func (this *_Clusters) GetEffective(name string) Interface {
 	return this.effective[name]
 }
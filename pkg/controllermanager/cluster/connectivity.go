@@ -0,0 +1,182 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package cluster
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/server/healthz"
+	"github.com/gardener/controller-manager-library/pkg/server/metrics"
+
+	"k8s.io/client-go/discovery"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+)
+
+const DEFAULT_CHECK_PERIOD = 30 * time.Second
+
+var reachable = metrics.NewGaugeVec("cluster_reachable",
+	"reachability of the API server of a cluster (1 = reachable)", "cluster")
+
+// ConnectivityHandler is notified about changes of the reachability
+// of a cluster.
+type ConnectivityHandler func(cluster Interface, reachable bool)
+
+// Connectivity describes the reachability of the API server of a cluster.
+// It is determined by periodic discovery requests and the results of
+// all other requests, including the watches of the informers.
+type Connectivity interface {
+	IsReachable() bool
+	LastError() error
+	Subscribe(h ConnectivityHandler)
+}
+
+type connectivity struct {
+	logger.LogContext
+	cluster Interface
+	period  time.Duration
+
+	lock      sync.Mutex
+	reachable bool
+	lastErr   error
+	handlers  []ConnectivityHandler
+}
+
+var _ Connectivity = &connectivity{}
+
+func newConnectivity(logger logger.LogContext, cluster Interface, period time.Duration) *connectivity {
+	if period <= 0 {
+		period = DEFAULT_CHECK_PERIOD
+	}
+	return &connectivity{
+		LogContext: logger.NewContext("connectivity", cluster.GetName()),
+		cluster:    cluster,
+		period:     period,
+		reachable:  true,
+	}
+}
+
+func (this *connectivity) IsReachable() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.reachable
+}
+
+func (this *connectivity) LastError() error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.lastErr
+}
+
+func (this *connectivity) Subscribe(h ConnectivityHandler) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.handlers = append(this.handlers, h)
+}
+
+func (this *connectivity) report(err error) {
+	this.lock.Lock()
+	this.lastErr = err
+	state := err == nil
+	if state == this.reachable {
+		this.lock.Unlock()
+		return
+	}
+	this.reachable = state
+	handlers := append([]ConnectivityHandler{}, this.handlers...)
+	this.lock.Unlock()
+
+	if state {
+		this.Infof("cluster %s is reachable again", this.cluster.GetName())
+		reachable.Set(1, this.cluster.GetName())
+	} else {
+		this.Errorf("cluster %s is unreachable: %s", this.cluster.GetName(), err)
+		reachable.Set(0, this.cluster.GetName())
+	}
+	for _, h := range handlers {
+		h(this.cluster, state)
+	}
+}
+
+// wrap adds a round tripper to the rest config recording the
+// transport errors of all requests.
+func (this *connectivity) wrap(cfg *restclient.Config) {
+	cfg.WrapTransport = transport.Wrappers(cfg.WrapTransport, func(rt http.RoundTripper) http.RoundTripper {
+		return &connectivityRoundTripper{this, rt}
+	})
+}
+
+func (this *connectivity) healthKey() string {
+	return "cluster:" + this.cluster.GetName()
+}
+
+// start starts the periodic reachability check until the context is done.
+func (this *connectivity) start(ctx context.Context, cfg *restclient.Config) error {
+	client, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	// the health check is opt-in, because unreachable optional or
+	// dynamic clusters should not affect the controller manager
+	health := this.cluster.Definition().IsHealthChecked()
+	reachable.Set(1, this.cluster.GetName())
+	if health {
+		healthz.Start(this.healthKey(), this.period)
+	}
+	go func() {
+		if health {
+			defer healthz.End(this.healthKey())
+		}
+		defer reachable.Delete(this.cluster.GetName())
+		ticker := time.NewTicker(this.period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, err := client.ServerVersion()
+				this.report(err)
+				if err == nil && health {
+					healthz.Tick(this.healthKey())
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+type connectivityRoundTripper struct {
+	connectivity *connectivity
+	delegate     http.RoundTripper
+}
+
+func (this *connectivityRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := this.delegate.RoundTrip(req)
+	if err != nil {
+		if req.Context().Err() == nil {
+			this.connectivity.report(err)
+		}
+	} else if resp.StatusCode < http.StatusInternalServerError {
+		this.connectivity.report(nil)
+	}
+	return resp, err
+}
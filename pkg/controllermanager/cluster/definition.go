@@ -25,6 +25,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/utils"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	restclient "k8s.io/client-go/rest"
 )
 
 const CLUSTERID_GROUP = "gardener.cloud"
@@ -43,6 +44,25 @@ type Definition interface {
 	Description() string
 	ConfigOptionName() string
 	Fallback() string
+
+	// RestConfig is a preset rest config used if no kubeconfig option is given
+	RestConfig() *restclient.Config
+	// KubeconfigData is a preset kubeconfig used if no kubeconfig option is given
+	KubeconfigData() []byte
+	// Context is the kubeconfig context used if no context option is given
+	Context() string
+
+	// IsDynamic indicates a cluster definition used for clusters
+	// discovered at runtime by kubeconfig secrets (see DynamicSource)
+	IsDynamic() bool
+	// DynamicHost is the name of the cluster hosting the kubeconfig secrets
+	DynamicHost() string
+	// DynamicSelector is the label selector for the kubeconfig secrets
+	DynamicSelector() string
+
+	// IsHealthChecked indicates that the reachability of the cluster is
+	// reported as health check. It is never used for dynamic clusters.
+	IsHealthChecked() bool
 }
 
 type _Definition struct {
@@ -50,10 +70,18 @@ type _Definition struct {
 	fallback         string
 	configOptionName string
 	description      string
+	restConfig       *restclient.Config
+	kubeconfigData   []byte
+	context          string
+	dynamic          bool
+	dynamicHost      string
+	dynamicSelector  string
+	healthChecked    bool
 }
 
 func copy(d Definition) *_Definition {
-	return &_Definition{d.Name(), d.Fallback(), d.ConfigOptionName(), d.Description()}
+	return &_Definition{d.Name(), d.Fallback(), d.ConfigOptionName(), d.Description(), d.RestConfig(), d.KubeconfigData(), d.Context(),
+		d.IsDynamic(), d.DynamicHost(), d.DynamicSelector(), d.IsHealthChecked()}
 }
 
 func (this *_Definition) Name() string {
@@ -68,6 +96,34 @@ func (this *_Definition) Description() string {
 func (this *_Definition) Fallback() string {
 	return this.fallback
 }
+func (this *_Definition) RestConfig() *restclient.Config {
+	return this.restConfig
+}
+func (this *_Definition) KubeconfigData() []byte {
+	return this.kubeconfigData
+}
+func (this *_Definition) Context() string {
+	return this.context
+}
+func (this *_Definition) IsDynamic() bool {
+	return this.dynamic
+}
+func (this *_Definition) DynamicHost() string {
+	if this.dynamicHost == "" {
+		return DEFAULT
+	}
+	return this.dynamicHost
+}
+func (this *_Definition) DynamicSelector() string {
+	return this.dynamicSelector
+}
+func (this *_Definition) IsHealthChecked() bool {
+	return this.healthChecked && !this.dynamic
+}
+
+func hasPreset(req Definition) bool {
+	return req.RestConfig() != nil || req.KubeconfigData() != nil
+}
 
 ////////////////////////////////////////////////////////////////////////////////
 
@@ -78,7 +134,35 @@ func (this *_Definitions) create(ctx context.Context, logger logger.LogContext,
 		id = idopt.StringValue()
 		logger.Infof("found id %q for cluster %q", id, req.Name())
 	}
-	cluster, err := CreateCluster(ctx, logger, req, id, option)
+	context := req.Context()
+	ctxopt := cfg.GetOption(req.ConfigOptionName() + SUBOPTION_CONTEXT)
+	if ctxopt != nil && ctxopt.Changed() {
+		context = ctxopt.StringValue()
+	}
+
+	var kubeConfig *restclient.Config
+	var err error
+	local := false
+	switch {
+	case option != "":
+		kubeConfig, local, err = loadKubeconfig(logger, req, id, option, context)
+	case req.RestConfig() != nil:
+		kubeConfig, err = copyRestConfig(logger, req, id, req.RestConfig())
+	case req.KubeconfigData() != nil:
+		kubeConfig, err = loadKubeconfigData(logger, req, id, req.KubeconfigData(), context)
+	default:
+		kubeConfig, local, err = loadKubeconfig(logger, req, id, "", context)
+	}
+	if err != nil {
+		return nil, err
+	}
+	GetClientSettings(cfg, req).Apply(kubeConfig)
+
+	cluster, err := createCluster(ctx, logger, req, id, kubeConfig, local, cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = detectId(logger, cfg, req, cluster, id)
 	if err != nil {
 		return nil, err
 	}
@@ -103,6 +187,15 @@ func (this *_Definitions) CreateClusters(ctx context.Context, logger logger.LogC
 
 	logger.Infof("required clusters: %s", names)
 
+	// dynamic clusters are discovered at runtime, only their host is required here
+	names = names.Copy()
+	for name := range names.Copy() {
+		if req := this.definitions[name]; req != nil && req.IsDynamic() {
+			names.Remove(name)
+			names.Add(req.DynamicHost())
+		}
+	}
+
 	lastFound := -1
 	missing := names
 	for len(missing) > 0 && lastFound != len(clusters.clusters) {
@@ -143,8 +236,12 @@ func (this *_Definitions) handleCluster(ctx context.Context, logger logger.LogCo
 	//	logger.Infof("  handle cluster %s (no config) fallback=%s", name, req.Fallback())
 	//}
 
-	if name != DEFAULT && opt != nil && opt.Changed() {
-		c, err = this.create(ctx, logger, cfg, req, opt.StringValue())
+	if name != DEFAULT && (opt != nil && opt.Changed() || hasPreset(req)) {
+		value := ""
+		if opt != nil && opt.Changed() {
+			value = opt.StringValue()
+		}
+		c, err = this.create(ctx, logger, cfg, req, value)
 		if err != nil {
 			return err
 		}
@@ -186,15 +283,37 @@ func (this *_Definitions) ExtendConfig(cfg *config.Config) {
 	defer this.lock.RUnlock()
 
 	for _, req := range this.definitions {
-		if req.ConfigOptionName() != "" {
+		if req.ConfigOptionName() != "" && req.IsDynamic() {
+			opt, _ := cfg.AddStringOption(req.ConfigOptionName() + SUBOPTION_SECRET_NAMESPACE)
+			opt.Description = fmt.Sprintf("namespace of kubeconfig secrets for dynamic cluster %s", req.Name())
+
+			opt, _ = cfg.AddStringOption(req.ConfigOptionName() + SUBOPTION_SECRET_SELECTOR)
+			opt.Description = fmt.Sprintf("label selector for kubeconfig secrets for dynamic cluster %s", req.Name())
+
+			opt, _ = cfg.AddBoolOption(req.ConfigOptionName() + SUBOPTION_DISABLE_DEPLOY_CRDS)
+			opt.Description = fmt.Sprintf("disable deployment of required crds for dynamic cluster %s", req.Name())
+
+			opt, _ = cfg.AddBoolOption(req.ConfigOptionName() + SUBOPTION_DETECT_ID)
+			opt.Description = fmt.Sprintf("detect ids for dynamic cluster %s", req.Name())
+
+			addClientSettingsOptions(cfg, req)
+		} else if req.ConfigOptionName() != "" {
 			opt, _ := cfg.AddStringOption(req.ConfigOptionName())
 			opt.Description = req.Description()
 
 			opt, _ = cfg.AddStringOption(req.ConfigOptionName() + SUBOPTION_ID)
 			opt.Description = fmt.Sprintf("id for cluster %s", req.Name())
 
+			opt, _ = cfg.AddBoolOption(req.ConfigOptionName() + SUBOPTION_DETECT_ID)
+			opt.Description = fmt.Sprintf("detect id for cluster %s", req.Name())
+
 			opt, _ = cfg.AddBoolOption(req.ConfigOptionName() + SUBOPTION_DISABLE_DEPLOY_CRDS)
 			opt.Description = fmt.Sprintf("disable deployment of required crds for cluster %s", req.Name())
+
+			opt, _ = cfg.AddStringOption(req.ConfigOptionName() + SUBOPTION_CONTEXT)
+			opt.Description = fmt.Sprintf("kubeconfig context used for cluster %s", req.Name())
+
+			addClientSettingsOptions(cfg, req)
 		}
 		callExtensions(func(e Extension) error { e.ExtendConfig(req, cfg); return nil })
 	}
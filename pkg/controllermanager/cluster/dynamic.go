@@ -0,0 +1,297 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/config"
+	"github.com/gardener/controller-manager-library/pkg/ctxutil"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const SUBOPTION_SECRET_NAMESPACE = ".secret-namespace"
+const SUBOPTION_SECRET_SELECTOR = ".secret-selector"
+
+// KUBECONFIG_KEY is the data key of a kubeconfig secret
+// describing a dynamic cluster.
+const KUBECONFIG_KEY = "kubeconfig"
+
+// DynamicClusterHandler is notified about clusters discovered
+// or removed by a DynamicSource. If a handler fails to handle an
+// added cluster, the cluster is removed again and recreated later on.
+type DynamicClusterHandler interface {
+	ClusterAdded(cluster Interface) error
+	ClusterRemoved(cluster Interface)
+}
+
+type dynamicSecret struct {
+	name resources.ObjectName
+	data []byte
+}
+
+type dynamicCluster struct {
+	cluster Interface
+	data    []byte
+	ctx     context.Context
+}
+
+// DynamicSource watches the kubeconfig secrets for a dynamic cluster
+// definition and maintains a cluster object for every secret.
+// Whenever the kubeconfig of a secret changes, the old cluster is
+// removed and a new one is created. Removed clusters are shut down
+// by cancelling their context, which stops all their informers.
+type DynamicSource struct {
+	logger.LogContext
+	ctx        context.Context
+	config     *config.Config
+	definition Definition
+	host       Interface
+	namespace  string
+	selector   string
+
+	queue    workqueue.RateLimitingInterface
+	lock     sync.Mutex
+	secrets  map[string]*dynamicSecret
+	clusters map[string]*dynamicCluster
+	handlers []DynamicClusterHandler
+}
+
+// NewDynamicSource creates a source for the given dynamic cluster definition.
+// The secrets are looked up in the namespace configured by the secret
+// namespace option of the cluster, or the given default namespace.
+func NewDynamicSource(ctx context.Context, logger logger.LogContext, cfg *config.Config, req Definition, host Interface, namespace string) (*DynamicSource, error) {
+	if !req.IsDynamic() {
+		return nil, fmt.Errorf("cluster %q is not dynamic", req.Name())
+	}
+	selector := req.DynamicSelector()
+	if req.ConfigOptionName() != "" {
+		if opt := cfg.GetOption(req.ConfigOptionName() + SUBOPTION_SECRET_NAMESPACE); opt != nil && opt.Changed() {
+			namespace = opt.StringValue()
+		}
+		if opt := cfg.GetOption(req.ConfigOptionName() + SUBOPTION_SECRET_SELECTOR); opt != nil && opt.Changed() {
+			selector = opt.StringValue()
+		}
+	}
+	if selector == "" {
+		return nil, fmt.Errorf("no secret selector for dynamic cluster %q", req.Name())
+	}
+	return &DynamicSource{
+		LogContext: logger.NewContext("dynamic", req.Name()),
+		ctx:        ctx,
+		config:     cfg,
+		definition: req,
+		host:       host,
+		namespace:  namespace,
+		selector:   selector,
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "dynamic:"+req.Name()),
+		secrets:    map[string]*dynamicSecret{},
+		clusters:   map[string]*dynamicCluster{},
+	}, nil
+}
+
+func (this *DynamicSource) GetDefinition() Definition {
+	return this.definition
+}
+
+// AddHandler adds a handler notified about added or removed clusters.
+// It should be called before the source is started.
+func (this *DynamicSource) AddHandler(h DynamicClusterHandler) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.handlers = append(this.handlers, h)
+}
+
+// GetClusters provides the currently known clusters.
+func (this *DynamicSource) GetClusters() []Interface {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	result := []Interface{}
+	for _, c := range this.clusters {
+		result = append(result, c.cluster)
+	}
+	return result
+}
+
+// Start starts watching the kubeconfig secrets. The event handlers
+// only record the kubeconfig of a secret, clusters are created and
+// removed by a worker, so a slow cluster does not block the events
+// of other secrets.
+func (this *DynamicSource) Start() error {
+	r, err := this.host.GetResource(schema.GroupKind{Group: corev1.GroupName, Kind: "Secret"})
+	if err != nil {
+		return err
+	}
+	this.Infof("watching secrets in namespace %q with selector %q of cluster %s", this.namespace, this.selector, this.host.GetName())
+	selector := this.selector
+	err = r.AddSelectedEventHandler(resources.ResourceEventHandlerFuncs{
+		AddFunc:    this.update,
+		UpdateFunc: func(old, new resources.Object) { this.update(new) },
+		DeleteFunc: this.delete,
+	}, this.namespace, func(opts *metav1.ListOptions) { opts.LabelSelector = selector })
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-this.ctx.Done()
+		this.queue.ShutDown()
+	}()
+	go this.run()
+	return nil
+}
+
+func (this *DynamicSource) update(obj resources.Object) {
+	secret, ok := obj.Data().(*corev1.Secret)
+	if !ok {
+		return
+	}
+	key := obj.ObjectName().String()
+
+	this.lock.Lock()
+	this.secrets[key] = &dynamicSecret{name: obj.ObjectName(), data: secret.Data[KUBECONFIG_KEY]}
+	this.lock.Unlock()
+	this.queue.Add(key)
+}
+
+func (this *DynamicSource) delete(obj resources.Object) {
+	key := obj.ObjectName().String()
+
+	this.lock.Lock()
+	delete(this.secrets, key)
+	this.lock.Unlock()
+	this.queue.Add(key)
+}
+
+func (this *DynamicSource) run() {
+	for {
+		key, shutdown := this.queue.Get()
+		if shutdown {
+			return
+		}
+		err := this.reconcile(key.(string))
+		if err != nil {
+			this.Errorf("cannot create cluster for secret %s: %s", key, err)
+			this.queue.AddRateLimited(key)
+		} else {
+			this.queue.Forget(key)
+		}
+		this.queue.Done(key)
+	}
+}
+
+// reconcile adjusts the cluster of a secret to the last recorded
+// kubeconfig. The lock is only held to access the maps, not while
+// creating or removing a cluster.
+func (this *DynamicSource) reconcile(key string) error {
+	this.lock.Lock()
+	secret := this.secrets[key]
+	old := this.clusters[key]
+	this.lock.Unlock()
+
+	if old != nil {
+		if secret != nil && bytes.Equal(old.data, secret.data) {
+			return nil
+		}
+		if secret != nil {
+			this.Infof("kubeconfig of secret %s changed", key)
+		} else {
+			this.Infof("secret %s deleted", key)
+		}
+		this.remove(key, old)
+	}
+	if secret == nil {
+		return nil
+	}
+	if len(secret.data) == 0 {
+		this.Warnf("secret %s has no %q entry", key, KUBECONFIG_KEY)
+		return nil
+	}
+
+	new, err := this.create(secret.name, secret.data)
+	if err != nil {
+		return err
+	}
+	this.lock.Lock()
+	this.clusters[key] = new
+	handlers := this.handlers
+	this.lock.Unlock()
+	for _, h := range handlers {
+		err := h.ClusterAdded(new.cluster)
+		if err != nil {
+			this.remove(key, new)
+			return err
+		}
+	}
+	return nil
+}
+
+func (this *DynamicSource) remove(key string, old *dynamicCluster) {
+	this.Infof("removing cluster %s", old.cluster.GetName())
+	this.lock.Lock()
+	delete(this.clusters, key)
+	handlers := this.handlers
+	this.lock.Unlock()
+	for _, h := range handlers {
+		h.ClusterRemoved(old.cluster)
+	}
+	ctxutil.Cancel(old.ctx)
+}
+
+func (this *DynamicSource) create(name resources.ObjectName, data []byte) (*dynamicCluster, error) {
+	req := copy(this.definition)
+	req.name = fmt.Sprintf("%s/%s/%s", this.definition.Name(), name.Namespace(), name.Name())
+
+	kubeConfig, err := loadKubeconfigData(this, req, req.name, data, "")
+	if err != nil {
+		return nil, err
+	}
+	GetClientSettings(this.config, req).Apply(kubeConfig)
+
+	ctx := ctxutil.CancelContext(this.ctx)
+	cluster, err := createCluster(ctx, this, req, req.name, kubeConfig, false, this.config)
+	if err != nil {
+		ctxutil.Cancel(ctx)
+		return nil, err
+	}
+	err = detectId(this, this.config, req, cluster, "")
+	if err != nil {
+		ctxutil.Cancel(ctx)
+		return nil, err
+	}
+	if req.ConfigOptionName() != "" {
+		crdsOpt := this.config.GetOption(req.ConfigOptionName() + SUBOPTION_DISABLE_DEPLOY_CRDS)
+		if crdsOpt != nil && crdsOpt.Changed() {
+			cluster.SetAttr(SUBOPTION_DISABLE_DEPLOY_CRDS, true)
+		}
+	}
+	err = callExtensions(func(e Extension) error { return e.Extend(cluster, this.config) })
+	if err != nil {
+		ctxutil.Cancel(ctx)
+		return nil, err
+	}
+	this.Infof("created cluster %s (%s)", cluster.GetName(), cluster.GetServerVersion().Original())
+	return &dynamicCluster{cluster: cluster, data: data, ctx: ctx}, nil
+}
@@ -0,0 +1,426 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	restclient "k8s.io/client-go/rest"
+)
+
+const SERVER_VERSION = "v1.16.0"
+
+// HISTORY_SIZE is the number of change events kept to serve
+// watches for older resource versions.
+const HISTORY_SIZE = 10000
+
+// Action describes a write request processed by the fake API server.
+type Action struct {
+	Verb        string
+	Resource    schema.GroupVersionResource
+	Subresource string
+	Namespace   string
+	Name        string
+	Object      *unstructured.Unstructured
+	DryRun      bool
+}
+
+func (this Action) String() string {
+	name := this.Name
+	if this.Namespace != "" {
+		name = this.Namespace + "/" + name
+	}
+	res := this.Resource.Resource
+	if this.Subresource != "" {
+		res += "/" + this.Subresource
+	}
+	if this.DryRun {
+		return fmt.Sprintf("%s %s %s (dry-run)", this.Verb, res, name)
+	}
+	return fmt.Sprintf("%s %s %s", this.Verb, res, name)
+}
+
+type resourceInfo struct {
+	schema.GroupVersionResource
+	kind         string
+	singular     string
+	namespaced   bool
+	subresources utils.StringSet
+}
+
+func (this *resourceInfo) GroupResource() schema.GroupResource {
+	return this.GroupVersionResource.GroupResource()
+}
+
+func (this *resourceInfo) GroupVersionKind() schema.GroupVersionKind {
+	return this.GroupVersion().WithKind(this.kind)
+}
+
+// APIServer is an in-memory API server for tests. It serves the
+// discovery, the CRUD operations and the watches for all resources
+// known by its schemes via HTTP, so that the regular clients, informers
+// and event recorders can be used. Creating a CRD registers its
+// resources. Objects are not converted between the versions of a
+// group, and there is no defaulting, validation or garbage collection.
+// Dry-run requests are executed exclusively on a copy of the state.
+type APIServer struct {
+	requests  sync.RWMutex
+	lock      sync.Mutex
+	schemes   []*runtime.Scheme
+	groups    map[string]map[string]map[string]*resourceInfo
+	objects   map[schema.GroupVersionResource]map[string]*unstructured.Unstructured
+	version   int64
+	history   []*event
+	compacted int64
+	watchers  map[*watcher]struct{}
+	actions   []Action
+	dryRun    bool
+
+	server *httptest.Server
+	done   chan struct{}
+}
+
+var clusterScoped = utils.NewStringSet(
+	"Namespace", "Node", "PersistentVolume", "ComponentStatus",
+	"CustomResourceDefinition", "APIService",
+	"ClusterRole", "ClusterRoleBinding", "PodSecurityPolicy",
+	"StorageClass", "VolumeAttachment", "CSIDriver", "CSINode",
+	"PriorityClass", "RuntimeClass", "CertificateSigningRequest",
+	"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration",
+	"TokenReview", "SubjectAccessReview", "SelfSubjectAccessReview", "SelfSubjectRulesReview",
+)
+
+// kinds not served as resources of their own
+var pseudoKinds = utils.NewStringSet(
+	"Scale", "Eviction", "EphemeralContainers", "PodStatusResult", "RangeAllocation", "JobTemplate",
+)
+
+// NewAPIServer creates a fake API server serving the object types of
+// the given schemes. By default the types of the library's default
+// scheme, the kubernetes client scheme and the CRD type are served.
+func NewAPIServer(schemes ...*runtime.Scheme) *APIServer {
+	if len(schemes) == 0 {
+		crds := runtime.NewScheme()
+		apiextensions.AddToScheme(crds)
+		schemes = []*runtime.Scheme{resources.DefaultScheme(), scheme.Scheme, crds}
+	}
+	this := &APIServer{
+		schemes:  schemes,
+		groups:   map[string]map[string]map[string]*resourceInfo{},
+		objects:  map[schema.GroupVersionResource]map[string]*unstructured.Unstructured{},
+		watchers: map[*watcher]struct{}{},
+		done:     make(chan struct{}),
+	}
+	for _, s := range schemes {
+		for gvk, t := range s.AllKnownTypes() {
+			this.addType(gvk, t)
+		}
+	}
+	return this
+}
+
+func (this *APIServer) addType(gvk schema.GroupVersionKind, t reflect.Type) {
+	if gvk.Version == runtime.APIVersionInternal || t.Kind() != reflect.Struct || pseudoKinds.Contains(gvk.Kind) {
+		return
+	}
+	if _, ok := t.FieldByName("ObjectMeta"); !ok {
+		return
+	}
+	plural, singular := meta.UnsafeGuessKindToResource(gvk)
+	if this.lookup(plural.GroupVersion(), plural.Resource) != nil {
+		return
+	}
+	sub := []string{}
+	if _, ok := t.FieldByName("Status"); ok {
+		sub = append(sub, "status")
+	}
+	if f, ok := t.FieldByName("Spec"); ok && f.Type.Kind() == reflect.Struct {
+		if _, ok := f.Type.FieldByName("Replicas"); ok {
+			sub = append(sub, "scale")
+		}
+	}
+	if gvk.Group == "" && gvk.Kind == "Pod" {
+		sub = append(sub, "eviction")
+	}
+	this.addResource(gvk, plural.Resource, singular.Resource, !clusterScoped.Contains(gvk.Kind), sub...)
+}
+
+// AddResource declares an additional resource, for example for a
+// custom resource handled as unstructured object.
+func (this *APIServer) AddResource(gvk schema.GroupVersionKind, plural string, namespaced bool, subresources ...string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	_, singular := meta.UnsafeGuessKindToResource(gvk)
+	this.addResource(gvk, plural, singular.Resource, namespaced, subresources...)
+}
+
+func (this *APIServer) addResource(gvk schema.GroupVersionKind, plural, singular string, namespaced bool, subresources ...string) {
+	versions := this.groups[gvk.Group]
+	if versions == nil {
+		versions = map[string]map[string]*resourceInfo{}
+		this.groups[gvk.Group] = versions
+	}
+	infos := versions[gvk.Version]
+	if infos == nil {
+		infos = map[string]*resourceInfo{}
+		versions[gvk.Version] = infos
+	}
+	infos[plural] = &resourceInfo{
+		GroupVersionResource: gvk.GroupVersion().WithResource(plural),
+		kind:                 gvk.Kind,
+		singular:             singular,
+		namespaced:           namespaced,
+		subresources:         utils.NewStringSet(subresources...),
+	}
+}
+
+func (this *APIServer) removeResource(gvr schema.GroupVersionResource) {
+	versions := this.groups[gvr.Group]
+	if versions == nil || versions[gvr.Version] == nil {
+		return
+	}
+	delete(versions[gvr.Version], gvr.Resource)
+	if len(versions[gvr.Version]) == 0 {
+		delete(versions, gvr.Version)
+	}
+	if len(versions) == 0 {
+		delete(this.groups, gvr.Group)
+	}
+	delete(this.objects, gvr)
+}
+
+func (this *APIServer) lookup(gv schema.GroupVersion, resource string) *resourceInfo {
+	return this.groups[gv.Group][gv.Version][resource]
+}
+
+func (this *APIServer) lookupKind(gvk schema.GroupVersionKind) *resourceInfo {
+	for _, info := range this.groups[gvk.Group][gvk.Version] {
+		if info.kind == gvk.Kind {
+			return info
+		}
+	}
+	return nil
+}
+
+func (this *APIServer) objectKind(obj runtime.Object) (schema.GroupVersionKind, error) {
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Kind != "" && gvk.Version != "" {
+		return gvk, nil
+	}
+	for _, s := range this.schemes {
+		gvks, _, err := s.ObjectKinds(obj)
+		if err == nil && len(gvks) > 0 {
+			return gvks[0], nil
+		}
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("unknown object type %T", obj)
+}
+
+func (this *APIServer) newTyped(gvk schema.GroupVersionKind) runtime.Object {
+	for _, s := range this.schemes {
+		if obj, err := s.New(gvk); err == nil {
+			return obj
+		}
+	}
+	return nil
+}
+
+// AddObjects adds initial objects to the server. They are stored as
+// given, including their status, and the change events are visible
+// for watches. Typed objects must be known by one of the schemes.
+func (this *APIServer) AddObjects(objs ...runtime.Object) error {
+	this.requests.RLock()
+	defer this.requests.RUnlock()
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for _, o := range objs {
+		gvk, err := this.objectKind(o)
+		if err != nil {
+			return err
+		}
+		info := this.lookupKind(gvk)
+		if info == nil {
+			return fmt.Errorf("no resource found for %s", gvk)
+		}
+		var obj *unstructured.Unstructured
+		if u, ok := o.(*unstructured.Unstructured); ok {
+			obj = u.DeepCopy()
+		} else {
+			data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+			if err != nil {
+				return err
+			}
+			obj = &unstructured.Unstructured{Object: data}
+		}
+		if !info.namespaced {
+			obj.SetNamespace("")
+		} else if obj.GetNamespace() == "" {
+			obj.SetNamespace("default")
+		}
+		if this.objects[info.GroupVersionResource][key(obj.GetNamespace(), obj.GetName())] != nil {
+			return fmt.Errorf("%s %s/%s already exists", info.Resource, obj.GetNamespace(), obj.GetName())
+		}
+		_, err = this.insert(info, obj, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Objects returns the actually stored objects of the given kind.
+// Kubernetes events emitted by the controllers can be checked by
+// requesting the kind Event of the core group.
+func (this *APIServer) Objects(gvk schema.GroupVersionKind) []*unstructured.Unstructured {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	info := this.lookupKind(gvk)
+	if info == nil {
+		return nil
+	}
+	result := []*unstructured.Unstructured{}
+	for _, o := range this.sorted(info, "") {
+		result = append(result, o.DeepCopy())
+	}
+	return result
+}
+
+// Version returns the actual resource version of the server.
+func (this *APIServer) Version() int64 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.version
+}
+
+// Actions returns the write requests processed so far.
+func (this *APIServer) Actions() []Action {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return append([]Action{}, this.actions...)
+}
+
+// ClearActions resets the recorded write requests.
+func (this *APIServer) ClearActions() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.actions = nil
+}
+
+func (this *APIServer) record(verb string, req *request, obj *unstructured.Unstructured) {
+	a := Action{
+		Verb:        verb,
+		Resource:    req.info.GroupVersionResource,
+		Subresource: req.subresource,
+		Namespace:   req.namespace,
+		Name:        req.name,
+		DryRun:      req.dryRun,
+	}
+	if obj != nil {
+		a.Object = obj.DeepCopy()
+		if a.Name == "" {
+			a.Name = obj.GetName()
+		}
+	}
+	this.actions = append(this.actions, a)
+}
+
+func (this *APIServer) sorted(info *resourceInfo, namespace string) []*unstructured.Unstructured {
+	keys := []string{}
+	for k, o := range this.objects[info.GroupVersionResource] {
+		if namespace == "" || o.GetNamespace() == namespace {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	result := make([]*unstructured.Unstructured, len(keys))
+	for i, k := range keys {
+		result[i] = this.objects[info.GroupVersionResource][k]
+	}
+	return result
+}
+
+// Start starts serving the API via a local HTTP listener.
+func (this *APIServer) Start() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.server == nil {
+		this.server = httptest.NewServer(this)
+	}
+}
+
+// URL returns the URL of the started server.
+func (this *APIServer) URL() string {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.server == nil {
+		return ""
+	}
+	return this.server.URL
+}
+
+// RestConfig provides a rest config for accessing the started server.
+func (this *APIServer) RestConfig() *restclient.Config {
+	return &restclient.Config{
+		Host:  this.URL(),
+		QPS:   1000,
+		Burst: 1000,
+	}
+}
+
+// Close terminates all watches and stops the server.
+func (this *APIServer) Close() {
+	this.lock.Lock()
+	server := this.server
+	if server != nil {
+		this.server = nil
+		close(this.done)
+	}
+	this.lock.Unlock()
+	if server != nil {
+		server.CloseClientConnections()
+		server.Close()
+	}
+}
+
+func (this *APIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if this.serveDiscovery(w, r) {
+		return
+	}
+	req, err := this.parseRequest(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	this.handle(w, r, req)
+}
+
+func key(namespace, name string) string {
+	return namespace + "/" + name
+}
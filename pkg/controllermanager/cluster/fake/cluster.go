@@ -0,0 +1,75 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"context"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Cluster is a cluster backed by a fake API server. It can be used
+// wherever a cluster.Interface or its resources are expected and
+// provides access to the server to prepare and check the objects
+// and the write requests.
+type Cluster struct {
+	cluster.Interface
+	Server *APIServer
+}
+
+// NewCluster creates a fake cluster serving the given initial objects.
+// The server is stopped when the context is done.
+func NewCluster(ctx context.Context, name string, objs ...runtime.Object) (*Cluster, error) {
+	return NewClusterForServer(ctx, name, NewAPIServer(), objs...)
+}
+
+// NewClusterForServer creates a fake cluster for an explicitly created
+// API server, for example to serve additional schemes.
+func NewClusterForServer(ctx context.Context, name string, server *APIServer, objs ...runtime.Object) (*Cluster, error) {
+	err := server.AddObjects(objs...)
+	if err != nil {
+		return nil, err
+	}
+	server.Start()
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+	def := cluster.Configure(name, "", "fake cluster "+name).Definition()
+	c, err := cluster.CreateClusterForRestConfig(ctx, logger.New(), def, name, server.RestConfig())
+	if err != nil {
+		server.Close()
+		return nil, err
+	}
+	return &Cluster{Interface: c, Server: server}, nil
+}
+
+// AddResource declares an additional resource at the server and
+// refreshes the discovery information of the cluster.
+func (this *Cluster) AddResource(gvk schema.GroupVersionKind, plural string, namespaced bool, subresources ...string) error {
+	this.Server.AddResource(gvk, plural, namespaced, subresources...)
+	return this.ResourceContext().Refresh()
+}
+
+// AddObjects adds objects to the server without recording write requests.
+func (this *Cluster) AddObjects(objs ...runtime.Object) error {
+	return this.Server.AddObjects(objs...)
+}
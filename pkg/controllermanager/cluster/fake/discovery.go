@@ -0,0 +1,194 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+var resourceVerbs = metav1.Verbs{"create", "delete", "deletecollection", "get", "list", "patch", "update", "watch"}
+var subresourceVerbs = metav1.Verbs{"get", "patch", "update"}
+
+// subresources with a kind of their own
+var subresourceTypes = map[string]metav1.APIResource{
+	"scale":    {Group: "autoscaling", Version: "v1", Kind: "Scale", Verbs: metav1.Verbs{"get", "update"}},
+	"eviction": {Group: "policy", Version: "v1beta1", Kind: "Eviction", Verbs: metav1.Verbs{"create"}},
+}
+
+// serveDiscovery handles the discovery endpoints. It returns false for
+// all other requests.
+func (this *APIServer) serveDiscovery(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	switch {
+	case len(parts) == 1 && parts[0] == "version":
+		writeJSON(w, http.StatusOK, this.serverVersion())
+	case len(parts) == 1 && parts[0] == "api":
+		writeJSON(w, http.StatusOK, this.coreVersions(r))
+	case len(parts) == 1 && parts[0] == "apis":
+		list := &metav1.APIGroupList{TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"}}
+		for _, g := range this.groupNames() {
+			list.Groups = append(list.Groups, *this.apiGroup(g))
+		}
+		writeJSON(w, http.StatusOK, list)
+	case len(parts) == 2 && parts[0] == "apis":
+		if this.groups[parts[1]] == nil {
+			writeError(w, apierrors.NewNotFound(schema.GroupResource{Group: parts[1]}, ""))
+		} else {
+			writeJSON(w, http.StatusOK, this.apiGroup(parts[1]))
+		}
+	case len(parts) == 2 && parts[0] == "api":
+		this.serveResources(w, schema.GroupVersion{Version: parts[1]})
+	case len(parts) == 3 && parts[0] == "apis":
+		this.serveResources(w, schema.GroupVersion{Group: parts[1], Version: parts[2]})
+	default:
+		return false
+	}
+	return true
+}
+
+func (this *APIServer) serverVersion() *version.Info {
+	v := strings.SplitN(strings.TrimPrefix(SERVER_VERSION, "v"), ".", 3)
+	return &version.Info{
+		Major:      v[0],
+		Minor:      v[1],
+		GitVersion: SERVER_VERSION,
+		Platform:   "fake",
+	}
+}
+
+func (this *APIServer) coreVersions(r *http.Request) *metav1.APIVersions {
+	result := &metav1.APIVersions{
+		TypeMeta: metav1.TypeMeta{Kind: "APIVersions"},
+		ServerAddressByClientCIDRs: []metav1.ServerAddressByClientCIDR{
+			{ClientCIDR: "0.0.0.0/0", ServerAddress: r.Host},
+		},
+	}
+	result.Versions = this.versions("")
+	return result
+}
+
+func (this *APIServer) groupNames() []string {
+	names := []string{}
+	for g := range this.groups {
+		if g != "" {
+			names = append(names, g)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// versions returns the versions of a group with the preferred
+// version first.
+func (this *APIServer) versions(group string) []string {
+	versions := []string{}
+	for v := range this.groups[group] {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return version.CompareKubeAwareVersionStrings(versions[i], versions[j]) > 0
+	})
+	preferred := this.preferredVersion(group)
+	result := []string{preferred}
+	for _, v := range versions {
+		if v != preferred {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// preferredVersion returns the declared default version of a group or
+// its most stable version.
+func (this *APIServer) preferredVersion(group string) string {
+	versions := this.groups[group]
+	if v := resources.DefaultVersion(group); versions[v] != nil {
+		return v
+	}
+	preferred := ""
+	for v := range versions {
+		if preferred == "" || version.CompareKubeAwareVersionStrings(v, preferred) > 0 {
+			preferred = v
+		}
+	}
+	return preferred
+}
+
+func (this *APIServer) apiGroup(group string) *metav1.APIGroup {
+	result := &metav1.APIGroup{
+		TypeMeta: metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"},
+		Name:     group,
+	}
+	for _, v := range this.versions(group) {
+		result.Versions = append(result.Versions, metav1.GroupVersionForDiscovery{
+			GroupVersion: schema.GroupVersion{Group: group, Version: v}.String(),
+			Version:      v,
+		})
+	}
+	result.PreferredVersion = result.Versions[0]
+	return result
+}
+
+func (this *APIServer) serveResources(w http.ResponseWriter, gv schema.GroupVersion) {
+	infos := this.groups[gv.Group][gv.Version]
+	if infos == nil {
+		writeError(w, apierrors.NewNotFound(schema.GroupResource{Group: gv.Group}, gv.Version))
+		return
+	}
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: gv.String(),
+	}
+	for _, info := range infos {
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:         info.Resource,
+			SingularName: info.singular,
+			Namespaced:   info.namespaced,
+			Kind:         info.kind,
+			Verbs:        resourceVerbs,
+		})
+		for sub := range info.subresources {
+			r, ok := subresourceTypes[sub]
+			if !ok {
+				r = metav1.APIResource{Kind: info.kind, Verbs: subresourceVerbs}
+			}
+			r.Name = info.Resource + "/" + sub
+			r.Namespaced = info.namespaced
+			list.APIResources = append(list.APIResources, r)
+		}
+	}
+	sort.Slice(list.APIResources, func(i, j int) bool {
+		return list.APIResources[i].Name < list.APIResources[j].Name
+	})
+	writeJSON(w, http.StatusOK, list)
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// simulate prepares the execution of a dry-run request. The changes of
+// the request are not reported to the watches, and the returned function
+// restores the previous state. Stored objects are never modified in place,
+// therefore copies of the maps are sufficient.
+func (this *APIServer) simulate() func() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	version := this.version
+	objects := map[schema.GroupVersionResource]map[string]*unstructured.Unstructured{}
+	for gvr, m := range this.objects {
+		c := make(map[string]*unstructured.Unstructured, len(m))
+		for k, o := range m {
+			c[k] = o
+		}
+		objects[gvr] = c
+	}
+	groups := map[string]map[string]map[string]*resourceInfo{}
+	for g, versions := range this.groups {
+		gc := map[string]map[string]*resourceInfo{}
+		for v, infos := range versions {
+			vc := map[string]*resourceInfo{}
+			for r, info := range infos {
+				vc[r] = info
+			}
+			gc[v] = vc
+		}
+		groups[g] = gc
+	}
+	this.dryRun = true
+
+	return func() {
+		this.lock.Lock()
+		defer this.lock.Unlock()
+		this.version = version
+		this.objects = objects
+		this.groups = groups
+		this.dryRun = false
+	}
+}
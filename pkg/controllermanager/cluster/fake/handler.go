@@ -0,0 +1,226 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+)
+
+type request struct {
+	info        *resourceInfo
+	namespace   string
+	name        string
+	subresource string
+	query       url.Values
+	as          string
+	dryRun      bool
+}
+
+func (this *APIServer) parseRequest(r *http.Request) (*request, error) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var gv schema.GroupVersion
+	switch {
+	case len(parts) > 2 && parts[0] == "api":
+		gv = schema.GroupVersion{Version: parts[1]}
+		parts = parts[2:]
+	case len(parts) > 3 && parts[0] == "apis":
+		gv = schema.GroupVersion{Group: parts[1], Version: parts[2]}
+		parts = parts[3:]
+	default:
+		return nil, apierrors.NewNotFound(schema.GroupResource{}, r.URL.Path)
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	req := &request{query: r.URL.Query(), as: metadataAs(r)}
+	req.dryRun = isDryRun(req.query["dryRun"])
+	if len(parts) > 2 && parts[0] == "namespaces" {
+		if info := this.lookup(gv, parts[2]); info != nil && info.namespaced {
+			req.namespace = parts[1]
+			parts = parts[2:]
+		}
+	}
+	req.info = this.lookup(gv, parts[0])
+	if req.info == nil || len(parts) > 3 {
+		return nil, apierrors.NewNotFound(gv.WithResource(parts[0]).GroupResource(), "")
+	}
+	if len(parts) > 1 {
+		req.name = parts[1]
+	}
+	if len(parts) > 2 {
+		if !req.info.subresources.Contains(parts[2]) {
+			return nil, apierrors.NewNotFound(req.info.GroupResource(), req.name+"/"+parts[2])
+		}
+		req.subresource = parts[2]
+	}
+	return req, nil
+}
+
+func (this *APIServer) handle(w http.ResponseWriter, r *http.Request, req *request) {
+	var result interface{}
+	var err error
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, apierrors.NewBadRequest(err.Error()))
+		return
+	}
+	if r.Method == http.MethodGet && req.name == "" && isTrue(req.query.Get("watch")) {
+		this.watch(w, r, req)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		if opts, err := deleteOptions(data); err == nil && isDryRun(opts.DryRun) {
+			req.dryRun = true
+		}
+	}
+	if req.dryRun {
+		this.requests.Lock()
+		defer this.requests.Unlock()
+		defer this.simulate()()
+	} else {
+		this.requests.RLock()
+		defer this.requests.RUnlock()
+	}
+
+	status := http.StatusOK
+	switch {
+	case req.subresource == "scale" && r.Method == http.MethodGet:
+		result, err = this.getScale(req)
+	case req.subresource == "scale" && r.Method == http.MethodPut:
+		result, err = this.updateScale(req, data)
+	case req.subresource == "eviction" && r.Method == http.MethodPost:
+		result, err = this.evict(req, data)
+		status = http.StatusCreated
+	case r.Method == http.MethodGet && req.name == "":
+		result, err = this.list(req)
+		if err == nil {
+			result, err = asMetadata(req, result)
+		}
+	case r.Method == http.MethodGet:
+		result, err = this.get(req)
+		if err == nil {
+			result, err = asMetadata(req, result)
+		}
+	case r.Method == http.MethodPost && req.name == "":
+		result, err = this.create(req, data)
+		status = http.StatusCreated
+	case r.Method == http.MethodPut && req.name != "":
+		result, err = this.update(req, data)
+	case r.Method == http.MethodPatch && req.name != "":
+		ct := strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0])
+		result, err = this.patch(req, types.PatchType(ct), data)
+	case r.Method == http.MethodDelete && req.name == "":
+		result, err = this.deleteCollection(req, data)
+	case r.Method == http.MethodDelete:
+		result, err = this.delete(req, data)
+	default:
+		err = apierrors.NewMethodNotSupported(req.info.GroupResource(), strings.ToLower(r.Method))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, result)
+}
+
+func isDryRun(values []string) bool {
+	for _, v := range values {
+		if v == metav1.DryRunAll {
+			return true
+		}
+	}
+	return false
+}
+
+func isTrue(v string) bool {
+	return v == "true" || v == "1"
+}
+
+func decode(data []byte) (*unstructured.Unstructured, error) {
+	obj := map[string]interface{}{}
+	err := utiljson.Unmarshal(data, &obj)
+	if err != nil {
+		return nil, apierrors.NewBadRequest("cannot decode object: " + err.Error())
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(obj)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	s := statusFor(err)
+	writeJSON(w, int(s.Code), s)
+}
+
+func statusFor(err error) *metav1.Status {
+	status, ok := err.(apierrors.APIStatus)
+	if !ok {
+		status = apierrors.NewInternalError(err)
+	}
+	s := status.Status()
+	s.Kind = "Status"
+	s.APIVersion = "v1"
+	return &s
+}
+
+type selector struct {
+	labels labels.Selector
+	fields fields.Selector
+}
+
+func newSelector(query url.Values) (*selector, error) {
+	l, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	f, err := fields.ParseSelector(query.Get("fieldSelector"))
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	return &selector{labels: l, fields: f}, nil
+}
+
+// matches checks the label selector and the field selector. Field
+// selectors are supported for the name and the namespace only.
+func (this *selector) matches(obj *unstructured.Unstructured) bool {
+	if !this.labels.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+	return this.fields.Matches(fields.Set{
+		"metadata.name":      obj.GetName(),
+		"metadata.namespace": obj.GetNamespace(),
+	})
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const METADATA_KIND = "PartialObjectMetadata"
+const METADATA_LIST_KIND = "PartialObjectMetadataList"
+
+// metadataAs determines the metadata only representation requested by
+// the Accept header of a request. It is empty, if complete objects are
+// requested.
+func metadataAs(r *http.Request) string {
+	for _, t := range strings.Split(r.Header.Get("Accept"), ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(t))
+		if err != nil || (mt != "application/json" && mt != "*/*") {
+			continue
+		}
+		if params["as"] == "" {
+			return ""
+		}
+		if params["g"] == metav1.GroupName && params["v"] == "v1" {
+			return params["as"]
+		}
+	}
+	return ""
+}
+
+// asMetadata converts the result of a request into the requested
+// metadata only representation.
+func asMetadata(req *request, result interface{}) (interface{}, error) {
+	obj, ok := result.(map[string]interface{})
+	if req.as == "" || !ok {
+		return result, nil
+	}
+	list := obj["items"] != nil && req.name == ""
+	switch {
+	case req.as == METADATA_KIND && !list:
+		return partialObject(obj), nil
+	case req.as == METADATA_LIST_KIND && list:
+		items := []interface{}{}
+		for _, i := range obj["items"].([]interface{}) {
+			items = append(items, partialObject(i.(map[string]interface{})))
+		}
+		return map[string]interface{}{
+			"apiVersion": metav1.SchemeGroupVersion.String(),
+			"kind":       METADATA_LIST_KIND,
+			"metadata":   obj["metadata"],
+			"items":      items,
+		}, nil
+	default:
+		return nil, apierrors.NewGenericServerResponse(http.StatusNotAcceptable, "get", req.info.GroupResource(), req.name,
+			fmt.Sprintf("%s not supported for this request", req.as), 0, false)
+	}
+}
+
+func partialObject(obj map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": metav1.SchemeGroupVersion.String(),
+		"kind":       METADATA_KIND,
+		"metadata":   obj["metadata"],
+	}
+}
@@ -0,0 +1,305 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// applyPatch applies a JSON, merge or strategic merge patch to a copy
+// of the given object. Strategic merge patches are supported for types
+// known by the schemes only.
+func (this *APIServer) applyPatch(req *request, old *unstructured.Unstructured, pt types.PatchType, data []byte) (map[string]interface{}, error) {
+	var result interface{}
+	var err error
+
+	original := old.DeepCopy().Object
+	switch pt {
+	case types.JSONPatchType:
+		ops := []interface{}{}
+		err = utiljson.Unmarshal(data, &ops)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		result, err = jsonPatch(original, ops)
+	case types.MergePatchType:
+		var patch interface{}
+		err = utiljson.Unmarshal(data, &patch)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		result = mergePatch(original, patch)
+	case types.StrategicMergePatchType:
+		typed := this.newTyped(req.info.GroupVersionKind())
+		if typed == nil {
+			return nil, unsupportedPatch(req, pt)
+		}
+		patch := map[string]interface{}{}
+		err = utiljson.Unmarshal(data, &patch)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(err.Error())
+		}
+		result, err = strategicpatch.StrategicMergeMapPatch(original, patch, typed)
+		if m, ok := result.(strategicpatch.JSONMap); ok {
+			result = map[string]interface{}(m)
+		}
+	default:
+		return nil, unsupportedPatch(req, pt)
+	}
+	if err != nil {
+		return nil, apierrors.NewGenericServerResponse(http.StatusUnprocessableEntity, "patch", req.info.GroupResource(), req.name, err.Error(), 0, false)
+	}
+	obj, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, apierrors.NewBadRequest("patch result is no object")
+	}
+	return obj, nil
+}
+
+func unsupportedPatch(req *request, pt types.PatchType) error {
+	return apierrors.NewGenericServerResponse(http.StatusUnsupportedMediaType, "patch", req.info.GroupResource(), req.name,
+		fmt.Sprintf("patch type %q not supported for %s", pt, req.info.Resource), 0, false)
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386).
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = mergePatch(t[k], v)
+		}
+	}
+	return t
+}
+
+// jsonPatch applies a JSON patch (RFC 6902).
+func jsonPatch(doc interface{}, ops []interface{}) (interface{}, error) {
+	var err error
+	for _, o := range ops {
+		op, ok := o.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid patch operation")
+		}
+		name, _ := op["op"].(string)
+		path, _ := op["path"].(string)
+		from, _ := op["from"].(string)
+		value := op["value"]
+		switch name {
+		case "add":
+			doc, err = pointerApply(doc, path, func(c interface{}, k string) (interface{}, error) {
+				return addValue(c, k, runtime.DeepCopyJSONValue(value))
+			})
+		case "remove":
+			doc, err = pointerApply(doc, path, func(c interface{}, k string) (interface{}, error) {
+				c, _, err := removeValue(c, k)
+				return c, err
+			})
+		case "replace":
+			doc, err = pointerApply(doc, path, func(c interface{}, k string) (interface{}, error) {
+				c, _, err := removeValue(c, k)
+				if err != nil {
+					return nil, err
+				}
+				return addValue(c, k, runtime.DeepCopyJSONValue(value))
+			})
+		case "move", "copy":
+			var v interface{}
+			if name == "move" {
+				doc, err = pointerApply(doc, from, func(c interface{}, k string) (interface{}, error) {
+					c, v, err = removeValue(c, k)
+					return c, err
+				})
+			} else {
+				v, err = pointerGet(doc, from)
+				v = runtime.DeepCopyJSONValue(v)
+			}
+			if err == nil {
+				doc, err = pointerApply(doc, path, func(c interface{}, k string) (interface{}, error) {
+					return addValue(c, k, v)
+				})
+			}
+		case "test":
+			var v interface{}
+			v, err = pointerGet(doc, path)
+			if err == nil && !reflect.DeepEqual(v, value) {
+				err = fmt.Errorf("test operation for %q failed", path)
+			}
+		default:
+			err = fmt.Errorf("unsupported patch operation %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+func pointerTokens(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", path)
+	}
+	tokens := strings.Split(path[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.Replace(strings.Replace(t, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+// pointerApply calls the given function for the container and the last
+// token of a JSON pointer and replaces the container by the result.
+func pointerApply(doc interface{}, path string, f func(c interface{}, k string) (interface{}, error)) (interface{}, error) {
+	tokens, err := pointerTokens(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("operation on document root not supported")
+	}
+	return walk(doc, tokens, f)
+}
+
+func walk(doc interface{}, tokens []string, f func(c interface{}, k string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return f(doc, tokens[0])
+	}
+	switch c := doc.(type) {
+	case map[string]interface{}:
+		child, ok := c[tokens[0]]
+		if !ok {
+			return nil, fmt.Errorf("path element %q not found", tokens[0])
+		}
+		n, err := walk(child, tokens[1:], f)
+		if err != nil {
+			return nil, err
+		}
+		c[tokens[0]] = n
+		return c, nil
+	case []interface{}:
+		i, err := index(tokens[0], len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		n, err := walk(c[i], tokens[1:], f)
+		if err != nil {
+			return nil, err
+		}
+		c[i] = n
+		return c, nil
+	default:
+		return nil, fmt.Errorf("path element %q not found", tokens[0])
+	}
+}
+
+func pointerGet(doc interface{}, path string) (interface{}, error) {
+	tokens, err := pointerTokens(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		switch c := doc.(type) {
+		case map[string]interface{}:
+			v, ok := c[t]
+			if !ok {
+				return nil, fmt.Errorf("path element %q not found", t)
+			}
+			doc = v
+		case []interface{}:
+			i, err := index(t, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = c[i]
+		default:
+			return nil, fmt.Errorf("path element %q not found", t)
+		}
+	}
+	return doc, nil
+}
+
+func index(token string, max int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	return i, nil
+}
+
+func addValue(container interface{}, k string, value interface{}) (interface{}, error) {
+	switch c := container.(type) {
+	case map[string]interface{}:
+		c[k] = value
+		return c, nil
+	case []interface{}:
+		if k == "-" {
+			return append(c, value), nil
+		}
+		i, err := index(k, len(c))
+		if err != nil {
+			return nil, err
+		}
+		c = append(c, nil)
+		copy(c[i+1:], c[i:])
+		c[i] = value
+		return c, nil
+	default:
+		return nil, fmt.Errorf("cannot add %q to non-container value", k)
+	}
+}
+
+func removeValue(container interface{}, k string) (interface{}, interface{}, error) {
+	switch c := container.(type) {
+	case map[string]interface{}:
+		v, ok := c[k]
+		if !ok {
+			return nil, nil, fmt.Errorf("path element %q not found", k)
+		}
+		delete(c, k)
+		return c, v, nil
+	case []interface{}:
+		i, err := index(k, len(c)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		v := c[i]
+		return append(c[:i], c[i+1:]...), v, nil
+	default:
+		return nil, nil, fmt.Errorf("path element %q not found", k)
+	}
+}
@@ -0,0 +1,624 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/yaml"
+)
+
+func (this *APIServer) next() string {
+	this.version++
+	return strconv.FormatInt(this.version, 10)
+}
+
+func notFound(req *request) error {
+	return apierrors.NewNotFound(req.info.GroupResource(), req.name)
+}
+
+func conflict(req *request, msg string, args ...interface{}) error {
+	return apierrors.NewConflict(req.info.GroupResource(), req.name, fmt.Errorf(msg, args...))
+}
+
+func (this *APIServer) current(req *request) (*unstructured.Unstructured, error) {
+	obj := this.objects[req.info.GroupVersionResource][key(req.namespace, req.name)]
+	if obj == nil {
+		return nil, notFound(req)
+	}
+	return obj, nil
+}
+
+func (this *APIServer) get(req *request) (interface{}, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	obj, err := this.current(req)
+	if err != nil {
+		return nil, err
+	}
+	return obj.Object, nil
+}
+
+func (this *APIServer) list(req *request) (interface{}, error) {
+	sel, err := newSelector(req.query)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := queryInt(req.query, "limit")
+	if err != nil {
+		return nil, err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	version, start, err := this.parseContinue(req.query.Get("continue"))
+	if err != nil {
+		return nil, err
+	}
+	items := []interface{}{}
+	next := ""
+	for _, o := range this.sorted(req.info, req.namespace) {
+		k := key(o.GetNamespace(), o.GetName())
+		if k <= start || !sel.matches(o) {
+			continue
+		}
+		if limit > 0 && int64(len(items)) == limit {
+			next = fmt.Sprintf("%d/%s", version, start)
+			break
+		}
+		items = append(items, o.Object)
+		start = k
+	}
+	list := this.newList(req.info, items)
+	if next != "" {
+		list["metadata"].(map[string]interface{})["continue"] = base64.RawURLEncoding.EncodeToString([]byte(next))
+	}
+	return list, nil
+}
+
+// parseContinue decodes a continue token of a paginated list. It contains
+// the version of the first list request and the key of the last object.
+// As for compacted versions of etcd, tokens for versions older than the
+// watch history are expired. Unlike the real API server, subsequent pages
+// reflect the actual state instead of the state of the first request.
+func (this *APIServer) parseContinue(token string) (int64, string, error) {
+	if token == "" {
+		return this.version, "", nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, "", apierrors.NewBadRequest("invalid continue token")
+	}
+	parts := strings.SplitN(string(data), "/", 2)
+	version, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || len(parts) != 2 {
+		return 0, "", apierrors.NewBadRequest("invalid continue token")
+	}
+	if version <= this.compacted {
+		return 0, "", apierrors.NewResourceExpired(fmt.Sprintf("the continue token for version %d is expired (%d)", version, this.compacted))
+	}
+	return version, parts[1], nil
+}
+
+func queryInt(query url.Values, name string) (int64, error) {
+	v := query.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, apierrors.NewBadRequest(fmt.Sprintf("invalid %s %q", name, v))
+	}
+	return i, nil
+}
+
+func (this *APIServer) newList(info *resourceInfo, items []interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": info.GroupVersion().String(),
+		"kind":       info.kind + "List",
+		"metadata": map[string]interface{}{
+			"resourceVersion": strconv.FormatInt(this.version, 10),
+		},
+		"items": items,
+	}
+}
+
+func (this *APIServer) create(req *request, data []byte) (interface{}, error) {
+	obj, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if !req.info.namespaced {
+		obj.SetNamespace("")
+	} else if obj.GetNamespace() == "" {
+		obj.SetNamespace(req.namespace)
+	} else if obj.GetNamespace() != req.namespace {
+		return nil, apierrors.NewBadRequest("the namespace of the provided object does not match the namespace sent on the request")
+	}
+	objects := this.objects[req.info.GroupVersionResource]
+	if obj.GetName() == "" {
+		if obj.GetGenerateName() == "" {
+			return nil, apierrors.NewInvalid(req.info.GroupVersionKind().GroupKind(), "",
+				field.ErrorList{field.Required(field.NewPath("metadata", "name"), "name or generateName is required")})
+		}
+		for {
+			obj.SetName(obj.GetGenerateName() + randomSuffix())
+			if objects[key(obj.GetNamespace(), obj.GetName())] == nil {
+				break
+			}
+		}
+	}
+	if objects[key(obj.GetNamespace(), obj.GetName())] != nil {
+		return nil, apierrors.NewAlreadyExists(req.info.GroupResource(), obj.GetName())
+	}
+	obj, err = this.insert(req.info, obj, false)
+	if err != nil {
+		return nil, err
+	}
+	this.record("create", req, obj)
+	return obj.Object, nil
+}
+
+// insert stores a new object. The system fields are set, for initial
+// objects given ones are kept. As for the real API server, the status
+// of objects with a status subresource cannot be set on creation.
+func (this *APIServer) insert(info *resourceInfo, obj *unstructured.Unstructured, initial bool) (*unstructured.Unstructured, error) {
+	obj.SetAPIVersion(info.GroupVersion().String())
+	obj.SetKind(info.kind)
+	if !initial {
+		if info.subresources.Contains("status") {
+			delete(obj.Object, "status")
+		}
+		obj.SetUID("")
+		obj.SetCreationTimestamp(metav1.Time{})
+		obj.SetGeneration(0)
+		obj.SetDeletionTimestamp(nil)
+	}
+	if obj.GetUID() == "" {
+		obj.SetUID(types.UID(uuid.New().String()))
+	}
+	if ts := obj.GetCreationTimestamp(); ts.IsZero() {
+		obj.SetCreationTimestamp(metav1.Now())
+	}
+	if obj.GetGeneration() == 0 {
+		obj.SetGeneration(1)
+	}
+	if isCRD(info) {
+		err := this.registerCRD(obj)
+		if err != nil {
+			return nil, err
+		}
+	}
+	obj.SetResourceVersion(this.next())
+
+	objects := this.objects[info.GroupVersionResource]
+	if objects == nil {
+		objects = map[string]*unstructured.Unstructured{}
+		this.objects[info.GroupVersionResource] = objects
+	}
+	objects[key(obj.GetNamespace(), obj.GetName())] = obj
+	this.emit(watch.Added, info, nil, obj)
+	return obj, nil
+}
+
+func (this *APIServer) update(req *request, data []byte) (interface{}, error) {
+	obj, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old, err := this.current(req)
+	if err != nil {
+		return nil, err
+	}
+	if obj.GetName() != req.name {
+		return nil, apierrors.NewBadRequest("the name of the object does not match the name on the URL")
+	}
+	obj, err = this.modify(req, old, obj)
+	if err != nil {
+		return nil, err
+	}
+	this.record("update", req, obj)
+	return obj.Object, nil
+}
+
+func (this *APIServer) patch(req *request, pt types.PatchType, data []byte) (interface{}, error) {
+	if pt == types.ApplyPatchType {
+		return this.apply(req, data)
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old, err := this.current(req)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := this.applyPatch(req, old, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	obj := &unstructured.Unstructured{Object: patched}
+	if obj.GetName() != req.name {
+		return nil, apierrors.NewBadRequest("the name of the object cannot be changed by a patch")
+	}
+	obj, err = this.modify(req, old, obj)
+	if err != nil {
+		return nil, err
+	}
+	this.record("patch", req, obj)
+	return obj.Object, nil
+}
+
+// apply emulates a server-side apply request. The applied configuration
+// is merged into an existing object, otherwise the object is created.
+// The ownership of fields is not tracked, therefore fields are never
+// removed by an apply and there are no conflicts among field managers.
+func (this *APIServer) apply(req *request, data []byte) (interface{}, error) {
+	manager := req.query.Get("fieldManager")
+	if manager == "" {
+		return nil, apierrors.NewBadRequest("fieldManager must be set for apply requests")
+	}
+	applied := map[string]interface{}{}
+	err := yaml.Unmarshal(data, &applied)
+	if err != nil {
+		return nil, apierrors.NewBadRequest("cannot decode applied configuration: " + err.Error())
+	}
+	cfg := &unstructured.Unstructured{Object: applied}
+	if cfg.GetName() != req.name {
+		return nil, apierrors.NewBadRequest("the name of the applied configuration does not match the name sent on the request")
+	}
+	if cfg.GetManagedFields() != nil {
+		return nil, apierrors.NewBadRequest("metadata.managedFields must be nil for apply requests")
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old, err := this.current(req)
+	if err != nil {
+		if !apierrors.IsNotFound(err) || req.subresource != "" {
+			return nil, err
+		}
+		if !req.info.namespaced {
+			cfg.SetNamespace("")
+		} else if cfg.GetNamespace() == "" {
+			cfg.SetNamespace(req.namespace)
+		} else if cfg.GetNamespace() != req.namespace {
+			return nil, apierrors.NewBadRequest("the namespace of the provided object does not match the namespace sent on the request")
+		}
+		setManager(cfg, manager)
+		obj, err := this.insert(req.info, cfg, false)
+		if err != nil {
+			return nil, err
+		}
+		this.record("apply", req, obj)
+		return obj.Object, nil
+	}
+	obj := &unstructured.Unstructured{Object: mergePatch(old.DeepCopy().Object, applied).(map[string]interface{})}
+	if req.subresource == "" {
+		setManager(obj, manager)
+	}
+	obj, err = this.modify(req, old, obj)
+	if err != nil {
+		return nil, err
+	}
+	this.record("apply", req, obj)
+	return obj.Object, nil
+}
+
+// setManager adds an apply entry for a field manager to the managed
+// fields. The fields itself are not recorded.
+func setManager(obj *unstructured.Unstructured, manager string) {
+	managed := obj.GetManagedFields()
+	for _, m := range managed {
+		if m.Manager == manager && m.Operation == metav1.ManagedFieldsOperationApply {
+			return
+		}
+	}
+	now := metav1.Now()
+	obj.SetManagedFields(append(managed, metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		APIVersion: obj.GetAPIVersion(),
+		Time:       &now,
+	}))
+}
+
+// modify replaces an object. An update of the main resource keeps the
+// status of objects with a status subresource, while an update of the
+// status subresource changes the status only. Updates without changes
+// keep the resource version.
+func (this *APIServer) modify(req *request, old, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if rv := obj.GetResourceVersion(); rv != "" && rv != old.GetResourceVersion() {
+		return nil, conflict(req, "the object has been modified; please apply your changes to the latest version and try again")
+	}
+	var new *unstructured.Unstructured
+	if req.subresource == "status" {
+		new = old.DeepCopy()
+		setStatus(new, obj)
+	} else {
+		new = obj.DeepCopy()
+		if req.info.subresources.Contains("status") {
+			setStatus(new, old)
+		}
+		new.SetAPIVersion(old.GetAPIVersion())
+		new.SetKind(old.GetKind())
+		new.SetNamespace(old.GetNamespace())
+		new.SetUID(old.GetUID())
+		new.SetCreationTimestamp(old.GetCreationTimestamp())
+		new.SetDeletionTimestamp(old.GetDeletionTimestamp())
+		new.SetGeneration(old.GetGeneration())
+		if !reflect.DeepEqual(spec(old), spec(new)) {
+			new.SetGeneration(old.GetGeneration() + 1)
+		}
+	}
+	new.SetResourceVersion(old.GetResourceVersion())
+	if reflect.DeepEqual(old.Object, new.Object) {
+		return old, nil
+	}
+	new.SetResourceVersion(this.next())
+	if new.GetDeletionTimestamp() != nil && len(new.GetFinalizers()) == 0 {
+		this.remove(req.info, new)
+		return new, nil
+	}
+	if isCRD(req.info) {
+		err := this.registerCRD(new)
+		if err != nil {
+			return nil, err
+		}
+	}
+	this.objects[req.info.GroupVersionResource][key(new.GetNamespace(), new.GetName())] = new
+	this.emit(watch.Modified, req.info, old, new)
+	return new, nil
+}
+
+func setStatus(dst, src *unstructured.Unstructured) {
+	if status, ok := src.Object["status"]; ok {
+		dst.Object["status"] = runtime.DeepCopyJSONValue(status)
+	} else {
+		delete(dst.Object, "status")
+	}
+}
+
+// spec returns the content of an object relevant for its generation.
+func spec(obj *unstructured.Unstructured) map[string]interface{} {
+	result := map[string]interface{}{}
+	for k, v := range obj.Object {
+		switch k {
+		case "apiVersion", "kind", "metadata", "status":
+		default:
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func (this *APIServer) delete(req *request, data []byte) (interface{}, error) {
+	opts, err := deleteOptions(data)
+	if err != nil {
+		return nil, err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old, err := this.current(req)
+	if err != nil {
+		return nil, err
+	}
+	err = checkPreconditions(req, opts, old)
+	if err != nil {
+		return nil, err
+	}
+	obj := this.deleteObject(req.info, old)
+	this.record("delete", req, obj)
+	return obj.Object, nil
+}
+
+// deleteCollection deletes all matching objects. As for the real API
+// server, the delete options are applied to every object. Objects not
+// matching the preconditions are kept, and a conflict is reported.
+func (this *APIServer) deleteCollection(req *request, data []byte) (interface{}, error) {
+	sel, err := newSelector(req.query)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := deleteOptions(data)
+	if err != nil {
+		return nil, err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	var failed error
+	items := []interface{}{}
+	for _, o := range this.sorted(req.info, req.namespace) {
+		if sel.matches(o) {
+			if err := checkPreconditions(req, opts, o); err != nil {
+				failed = err
+				continue
+			}
+			obj := this.deleteObject(req.info, o)
+			this.record("deletecollection", req, obj)
+			items = append(items, obj.Object)
+		}
+	}
+	if failed != nil {
+		return nil, failed
+	}
+	return this.newList(req.info, items), nil
+}
+
+func deleteOptions(data []byte) (*metav1.DeleteOptions, error) {
+	opts := &metav1.DeleteOptions{}
+	if len(data) > 0 {
+		err := json.Unmarshal(data, opts)
+		if err != nil {
+			return nil, apierrors.NewBadRequest("cannot decode delete options: " + err.Error())
+		}
+	}
+	return opts, nil
+}
+
+func checkPreconditions(req *request, opts *metav1.DeleteOptions, old *unstructured.Unstructured) error {
+	if p := opts.Preconditions; p != nil {
+		if p.UID != nil && *p.UID != old.GetUID() {
+			return apierrors.NewConflict(req.info.GroupResource(), old.GetName(),
+				fmt.Errorf("the UID in the precondition (%s) does not match the UID in record (%s)", *p.UID, old.GetUID()))
+		}
+		if p.ResourceVersion != nil && *p.ResourceVersion != old.GetResourceVersion() {
+			return apierrors.NewConflict(req.info.GroupResource(), old.GetName(),
+				fmt.Errorf("the ResourceVersion in the precondition (%s) does not match the ResourceVersion in record (%s)", *p.ResourceVersion, old.GetResourceVersion()))
+		}
+	}
+	return nil
+}
+
+// deleteObject removes an object. Objects with finalizers are only
+// marked for deletion, they are removed by the update removing the
+// last finalizer.
+func (this *APIServer) deleteObject(info *resourceInfo, old *unstructured.Unstructured) *unstructured.Unstructured {
+	new := old.DeepCopy()
+	if len(old.GetFinalizers()) > 0 {
+		if old.GetDeletionTimestamp() != nil {
+			return old
+		}
+		now := metav1.Now()
+		new.SetDeletionTimestamp(&now)
+		new.SetResourceVersion(this.next())
+		this.objects[info.GroupVersionResource][key(new.GetNamespace(), new.GetName())] = new
+		this.emit(watch.Modified, info, old, new)
+		return new
+	}
+	new.SetResourceVersion(this.next())
+	this.remove(info, new)
+	return new
+}
+
+func (this *APIServer) remove(info *resourceInfo, obj *unstructured.Unstructured) {
+	delete(this.objects[info.GroupVersionResource], key(obj.GetNamespace(), obj.GetName()))
+	this.emit(watch.Deleted, info, nil, obj)
+	if isCRD(info) {
+		this.unregisterCRD(obj)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// custom resource definitions
+
+func isCRD(info *resourceInfo) bool {
+	return info.Group == apiextensions.GroupName && info.kind == "CustomResourceDefinition"
+}
+
+func crdVersions(crd *apiextensions.CustomResourceDefinition) []apiextensions.CustomResourceDefinitionVersion {
+	if len(crd.Spec.Versions) == 0 && crd.Spec.Version != "" {
+		return []apiextensions.CustomResourceDefinitionVersion{{Name: crd.Spec.Version, Served: true, Storage: true}}
+	}
+	return crd.Spec.Versions
+}
+
+// registerCRD serves the resources of a CRD and marks it as established.
+func (this *APIServer) registerCRD(obj *unstructured.Unstructured) error {
+	crd := &apiextensions.CustomResourceDefinition{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd)
+	if err != nil {
+		return apierrors.NewBadRequest(err.Error())
+	}
+	names := crd.Spec.Names
+	storage := []string{}
+	for _, v := range crdVersions(crd) {
+		if v.Storage {
+			storage = append(storage, v.Name)
+		}
+		if !v.Served {
+			continue
+		}
+		sub := crd.Spec.Subresources
+		if v.Subresources != nil {
+			sub = v.Subresources
+		}
+		subresources := []string{}
+		if sub != nil && sub.Status != nil {
+			subresources = append(subresources, "status")
+		}
+		singular := names.Singular
+		if singular == "" {
+			_, s := meta.UnsafeGuessKindToResource(schema.GroupVersionKind{Kind: names.Kind})
+			singular = s.Resource
+		}
+		gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: names.Kind}
+		this.addResource(gvk, names.Plural, singular, crd.Spec.Scope != apiextensions.ClusterScoped, subresources...)
+	}
+
+	now := metav1.Now()
+	crd.Status.AcceptedNames = names
+	crd.Status.StoredVersions = storage
+	crd.Status.Conditions = []apiextensions.CustomResourceDefinitionCondition{
+		{Type: apiextensions.NamesAccepted, Status: apiextensions.ConditionTrue, Reason: "NoConflicts", LastTransitionTime: now},
+		{Type: apiextensions.Established, Status: apiextensions.ConditionTrue, Reason: "InitialNamesAccepted", LastTransitionTime: now},
+	}
+	status, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&crd.Status)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	obj.Object["status"] = status
+	return nil
+}
+
+func (this *APIServer) unregisterCRD(obj *unstructured.Unstructured) {
+	crd := &apiextensions.CustomResourceDefinition{}
+	if runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd) != nil {
+		return
+	}
+	for _, v := range crdVersions(crd) {
+		this.removeResource(schema.GroupVersionResource{Group: crd.Spec.Group, Version: v.Name, Resource: crd.Spec.Names.Plural})
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+const suffixChars = "bcdfghjklmnpqrstvwxz2456789"
+
+func randomSuffix() string {
+	b := make([]byte, 5)
+	for i := range b {
+		b[i] = suffixChars[rand.Intn(len(suffixChars))]
+	}
+	return string(b)
+}
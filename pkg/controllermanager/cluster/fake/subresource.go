@@ -0,0 +1,126 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+
+	policy "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// scaleFor provides the autoscaling/v1 scale of an object with replicas.
+func scaleFor(obj *unstructured.Unstructured) map[string]interface{} {
+	replicas, _, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	current, _, _ := unstructured.NestedInt64(obj.Object, "status", "replicas")
+	selector := ""
+	if m, ok, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels"); ok {
+		selector = labels.SelectorFromSet(m).String()
+	}
+	scale := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"replicas": replicas},
+		"status": map[string]interface{}{"replicas": current, "selector": selector},
+	}}
+	scale.SetAPIVersion("autoscaling/v1")
+	scale.SetKind("Scale")
+	scale.SetName(obj.GetName())
+	scale.SetNamespace(obj.GetNamespace())
+	scale.SetUID(obj.GetUID())
+	scale.SetResourceVersion(obj.GetResourceVersion())
+	scale.SetCreationTimestamp(obj.GetCreationTimestamp())
+	return scale.Object
+}
+
+func (this *APIServer) getScale(req *request) (interface{}, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	obj, err := this.current(req)
+	if err != nil {
+		return nil, err
+	}
+	return scaleFor(obj), nil
+}
+
+// updateScale sets the replicas of the object. There is no controller
+// adapting the status to the new number of replicas.
+func (this *APIServer) updateScale(req *request, data []byte) (interface{}, error) {
+	scale, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	replicas, _, err := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+	if err != nil {
+		return nil, apierrors.NewBadRequest("invalid scale: " + err.Error())
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old, err := this.current(req)
+	if err != nil {
+		return nil, err
+	}
+	if scale.GetName() != req.name {
+		return nil, apierrors.NewBadRequest("the name of the object does not match the name on the URL")
+	}
+	obj := old.DeepCopy()
+	obj.SetResourceVersion(scale.GetResourceVersion())
+	unstructured.SetNestedField(obj.Object, replicas, "spec", "replicas")
+	obj, err = this.modify(req, old, obj)
+	if err != nil {
+		return nil, err
+	}
+	this.record("update", req, obj)
+	return scaleFor(obj), nil
+}
+
+// evict deletes a pod. Pod disruption budgets are not checked.
+func (this *APIServer) evict(req *request, data []byte) (interface{}, error) {
+	eviction := &policy.Eviction{}
+	err := json.Unmarshal(data, eviction)
+	if err != nil {
+		return nil, apierrors.NewBadRequest("cannot decode eviction: " + err.Error())
+	}
+	if eviction.Name != req.name {
+		return nil, apierrors.NewBadRequest("name in URL does not match name in Eviction object")
+	}
+	opts := eviction.DeleteOptions
+	if opts == nil {
+		opts = &metav1.DeleteOptions{}
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old, err := this.current(req)
+	if err != nil {
+		return nil, err
+	}
+	err = checkPreconditions(req, opts, old)
+	if err != nil {
+		return nil, err
+	}
+	obj := this.deleteObject(req.info, old)
+	this.record("create", req, obj)
+	return &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusSuccess,
+		Code:     http.StatusCreated,
+	}, nil
+}
@@ -0,0 +1,263 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WATCH_BUFFER is the number of events buffered for a watch. Watches
+// of clients not consuming their events fast enough are closed.
+const WATCH_BUFFER = 1000
+
+type event struct {
+	version int64
+	typ     watch.EventType
+	gvr     schema.GroupVersionResource
+	old     *unstructured.Unstructured
+	obj     *unstructured.Unstructured
+}
+
+type watchItem struct {
+	typ watch.EventType
+	obj interface{}
+}
+
+type watcher struct {
+	info      *resourceInfo
+	namespace string
+	selector  *selector
+	ch        chan *watchItem
+	pending   int
+}
+
+// filter maps a change event to the event seen by the watch. Objects
+// entering or leaving the selection are reported as added or deleted.
+func (this *watcher) filter(ev *event) *watchItem {
+	if ev.gvr != this.info.GroupVersionResource {
+		return nil
+	}
+	if this.namespace != "" && ev.obj.GetNamespace() != this.namespace {
+		return nil
+	}
+	match := this.selector.matches(ev.obj)
+	typ := ev.typ
+	if typ == watch.Modified {
+		old := this.selector.matches(ev.old)
+		switch {
+		case match && !old:
+			typ = watch.Added
+		case !match && old:
+			typ = watch.Deleted
+			match = true
+		}
+	}
+	if !match {
+		return nil
+	}
+	return &watchItem{typ: typ, obj: ev.obj.Object}
+}
+
+// Compact drops the history of changes, like a compaction of etcd.
+// Afterwards, watches for older resource versions and the continuation
+// of paginated lists started before fail with "410 Gone".
+func (this *APIServer) Compact() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.compacted = this.version
+	this.history = nil
+	this.next()
+}
+
+// emit records a change and distributes it to the active watches.
+func (this *APIServer) emit(typ watch.EventType, info *resourceInfo, old, obj *unstructured.Unstructured) {
+	if this.dryRun {
+		return
+	}
+	ev := &event{version: this.version, typ: typ, gvr: info.GroupVersionResource, old: old, obj: obj}
+	this.history = append(this.history, ev)
+	if len(this.history) > HISTORY_SIZE {
+		this.compacted = this.history[0].version
+		this.history = this.history[1:]
+	}
+	for w := range this.watchers {
+		if item := w.filter(ev); item != nil {
+			select {
+			case w.ch <- item:
+				w.pending++
+			default:
+				this.stopWatch(w)
+			}
+		}
+	}
+}
+
+// startWatch registers a watch and provides the events preceding the
+// registration. Without a resource version all matching objects are
+// reported as added.
+func (this *APIServer) startWatch(w *watcher, version string) ([]*watchItem, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	pending := []*watchItem{}
+	if version == "" || version == "0" {
+		for _, o := range this.sorted(w.info, w.namespace) {
+			if w.selector.matches(o) {
+				pending = append(pending, &watchItem{typ: watch.Added, obj: o.Object})
+			}
+		}
+	} else {
+		from, err := strconv.ParseInt(version, 10, 64)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid resource version %q", version))
+		}
+		if from < this.compacted {
+			return nil, apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", from, this.compacted))
+		}
+		for _, ev := range this.history {
+			if ev.version > from {
+				if item := w.filter(ev); item != nil {
+					pending = append(pending, item)
+				}
+			}
+		}
+	}
+	w.pending = len(pending)
+	this.watchers[w] = struct{}{}
+	return pending, nil
+}
+
+// IsSynced checks whether all change events have been sent to the
+// active watches.
+func (this *APIServer) IsSynced() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for w := range this.watchers {
+		if w.pending > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (this *APIServer) sent(w *watcher) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	w.pending--
+}
+
+func (this *APIServer) stopWatch(w *watcher) {
+	if _, ok := this.watchers[w]; ok {
+		delete(this.watchers, w)
+		close(w.ch)
+	}
+}
+
+func (this *APIServer) watch(w http.ResponseWriter, r *http.Request, req *request) {
+	if req.as != "" && req.as != METADATA_KIND {
+		writeError(w, apierrors.NewGenericServerResponse(http.StatusNotAcceptable, "watch", req.info.GroupResource(), "",
+			fmt.Sprintf("%s not supported for watches", req.as), 0, false))
+		return
+	}
+	sel, err := newSelector(req.query)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	watcher := &watcher{
+		info:      req.info,
+		namespace: req.namespace,
+		selector:  sel,
+		ch:        make(chan *watchItem, WATCH_BUFFER),
+	}
+	pending, err := this.startWatch(watcher, req.query.Get("resourceVersion"))
+	if err != nil && !apierrors.IsResourceExpired(err) {
+		writeError(w, err)
+		return
+	}
+	defer func() {
+		this.lock.Lock()
+		defer this.lock.Unlock()
+		this.stopWatch(watcher)
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	send := func(item *watchItem) bool {
+		obj := item.obj
+		if o, ok := obj.(map[string]interface{}); ok && req.as != "" {
+			obj = partialObject(o)
+		}
+		raw, err := json.Marshal(obj)
+		if err == nil {
+			err = enc.Encode(&metav1.WatchEvent{Type: string(item.typ), Object: runtime.RawExtension{Raw: raw}})
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		this.sent(watcher)
+		return err == nil
+	}
+
+	if err != nil {
+		raw, _ := json.Marshal(statusFor(err))
+		enc.Encode(&metav1.WatchEvent{Type: string(watch.Error), Object: runtime.RawExtension{Raw: raw}})
+		return
+	}
+	for _, item := range pending {
+		if !send(item) {
+			return
+		}
+	}
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	var timeout <-chan time.Time
+	if s, err := strconv.Atoi(req.query.Get("timeoutSeconds")); err == nil && s > 0 {
+		timer := time.NewTimer(time.Duration(s) * time.Second)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		select {
+		case item, ok := <-watcher.ch:
+			if !ok || !send(item) {
+				return
+			}
+		case <-r.Context().Done():
+			return
+		case <-this.done:
+			return
+		case <-timeout:
+			return
+		}
+	}
+}
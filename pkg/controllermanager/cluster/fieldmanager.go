@@ -0,0 +1,67 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package cluster
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// fieldManagerCluster is a view of a cluster, whose resources use an own
+// default field manager for server side apply requests.
+type fieldManagerCluster struct {
+	Interface
+	resources resources.Resources
+}
+
+// WithFieldManager provides a view of a cluster, whose resources use the
+// given name as default field manager for server side apply requests
+// (see resources.ResourceContext.ResourcesFor).
+func WithFieldManager(cluster Interface, fieldManager string) Interface {
+	if v, ok := cluster.(*fieldManagerCluster); ok {
+		cluster = v.Interface
+	}
+	return &fieldManagerCluster{
+		Interface: cluster,
+		resources: cluster.ResourceContext().ResourcesFor(fieldManager),
+	}
+}
+
+func (this *fieldManagerCluster) Resources() resources.Resources {
+	return this.resources
+}
+
+func (this *fieldManagerCluster) GetObject(spec interface{}) (resources.Object, error) {
+	return this.resources.GetObject(spec)
+}
+
+func (this *fieldManagerCluster) GetObjectInto(name resources.ObjectName, data resources.ObjectData) (resources.Object, error) {
+	return this.resources.GetObjectInto(name, data)
+}
+
+func (this *fieldManagerCluster) GetCachedObject(spec interface{}) (resources.Object, error) {
+	return this.resources.GetCachedObject(spec)
+}
+
+func (this *fieldManagerCluster) GetResource(groupKind schema.GroupKind) (resources.Interface, error) {
+	return this.resources.Get(groupKind)
+}
+
+func (this *fieldManagerCluster) String() string {
+	return this.GetName()
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/config"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const SUBOPTION_DETECT_ID = ".detect-id"
+
+// The well-known config map describing the identity of a cluster.
+const CLUSTER_IDENTITY_NAMESPACE = "kube-system"
+const CLUSTER_IDENTITY_CONFIGMAP = "cluster-identity"
+const CLUSTER_IDENTITY_KEY = "cluster-identity"
+
+// DetectClusterId determines the identity of a cluster. It is taken from
+// the well-known cluster identity config map, if present, or the uid of
+// the kube-system namespace.
+func DetectClusterId(cluster Interface) (string, error) {
+	cm := &corev1.ConfigMap{}
+	_, err := cluster.GetObjectInto(resources.NewObjectName(CLUSTER_IDENTITY_NAMESPACE, CLUSTER_IDENTITY_CONFIGMAP), cm)
+	if err == nil {
+		if id := cm.Data[CLUSTER_IDENTITY_KEY]; id != "" {
+			return id, nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return "", err
+	}
+
+	ns := &corev1.Namespace{}
+	_, err = cluster.GetObjectInto(resources.NewObjectName("", CLUSTER_IDENTITY_NAMESPACE), ns)
+	if err != nil {
+		return "", err
+	}
+	if ns.UID == "" {
+		return "", fmt.Errorf("no uid found for namespace %s", CLUSTER_IDENTITY_NAMESPACE)
+	}
+	return string(ns.UID), nil
+}
+
+// detectId sets the detected id for a cluster, if requested by the
+// detect id option. An explicitly configured id is kept, but a
+// mismatch with the detected id is reported.
+func detectId(logger logger.LogContext, cfg *config.Config, req Definition, cluster Interface, id string) error {
+	if req.ConfigOptionName() == "" {
+		return nil
+	}
+	opt := cfg.GetOption(req.ConfigOptionName() + SUBOPTION_DETECT_ID)
+	if opt == nil || !opt.BoolValue() {
+		return nil
+	}
+	detected, err := DetectClusterId(cluster)
+	if err != nil {
+		return fmt.Errorf("cannot detect id for cluster %q: %s", req.Name(), err)
+	}
+	if id != "" {
+		if id != detected {
+			logger.Warnf("configured id %q for cluster %q differs from detected id %q", id, req.Name(), detected)
+		}
+		return nil
+	}
+	logger.Infof("detected id %q for cluster %q", detected, req.Name())
+	if c, ok := cluster.(interface{ SetId(string) }); ok {
+		c.SetId(detected)
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	"k8s.io/apimachinery/pkg/runtime"
+	restclient "k8s.io/client-go/rest"
 	"sync"
 )
 
@@ -62,7 +63,7 @@ func NewRegistry(scheme *runtime.Scheme) Registry {
 		scheme = resources.DefaultScheme()
 	}
 	registry := &_Registry{_Definitions: &_Definitions{definitions: Registrations{}, scheme: scheme}}
-	Configure(DEFAULT, "kubeconfig", "default cluster access").MustRegisterAt(registry)
+	Configure(DEFAULT, "kubeconfig", "default cluster access").HealthCheck().MustRegisterAt(registry)
 	return registry
 }
 
@@ -87,7 +88,7 @@ func (this *_Registry) RegisterCluster(reg Registerable) error {
 	defer this.lock.Unlock()
 
 	if old := this.definitions[def.Name()]; old != nil {
-		msg := fmt.Sprintf("cluster request for %q")
+		msg := fmt.Sprintf("cluster request for %q", def.Name())
 		new := copy(old)
 		err := utils.FillStringValue(msg, &new.configOptionName, def.ConfigOptionName())
 		if err != nil {
@@ -97,6 +98,24 @@ func (this *_Registry) RegisterCluster(reg Registerable) error {
 		if err != nil {
 			return err
 		}
+		err = utils.FillStringValue(msg, &new.context, def.Context())
+		if err != nil {
+			return err
+		}
+		if def.RestConfig() != nil {
+			new.restConfig = def.RestConfig()
+		}
+		if def.KubeconfigData() != nil {
+			new.kubeconfigData = def.KubeconfigData()
+		}
+		if def.IsDynamic() {
+			new.dynamic = true
+			new.dynamicHost = def.DynamicHost()
+			new.dynamicSelector = def.DynamicSelector()
+		}
+		if def.IsHealthChecked() {
+			new.healthChecked = true
+		}
 		def = new
 	}
 	this.definitions[def.Name()] = def
@@ -148,7 +167,47 @@ type Configuration struct {
 var _ Registerable = Configuration{}
 
 func Configure(name string, option string, short string) Configuration {
-	return Configuration{_Definition{name, "", option, short}}
+	return Configuration{_Definition{name: name, configOptionName: option, description: short}}
+}
+
+// RestConfig presets the rest config used for the cluster,
+// if no kubeconfig is given by the command line.
+func (this Configuration) RestConfig(cfg *restclient.Config) Configuration {
+	this.definition.restConfig = cfg
+	return this
+}
+
+// KubeconfigData presets the kubeconfig used for the cluster,
+// if no kubeconfig is given by the command line.
+func (this Configuration) KubeconfigData(data []byte) Configuration {
+	this.definition.kubeconfigData = data
+	return this
+}
+
+// Context sets the default context used for the kubeconfig
+// of the cluster.
+func (this Configuration) Context(name string) Configuration {
+	this.definition.context = name
+	return this
+}
+
+// Dynamic declares the cluster to be discovered at runtime. Every
+// kubeconfig secret matching the label selector found in the given
+// host cluster provides a separate cluster. Controllers using this
+// cluster are instantiated once per discovered cluster.
+func (this Configuration) Dynamic(host string, selector string) Configuration {
+	this.definition.dynamic = true
+	this.definition.dynamicHost = host
+	this.definition.dynamicSelector = selector
+	return this
+}
+
+// HealthCheck reports the reachability of the cluster as health check
+// of the controller manager. It is ignored for dynamic clusters, whose
+// reachability is only reported by metrics and logs.
+func (this Configuration) HealthCheck() Configuration {
+	this.definition.healthChecked = true
+	return this
 }
 
 func (this Configuration) Fallback(name string) Configuration {
@@ -35,9 +35,24 @@ type Config struct {
 	Name                        string
 	Namespace                   string
 	OmitLease                   bool
+	DryRun                      bool
 	DisableNamespaceRestriction bool
 	NamespaceRestriction        bool
 	ServerPortHTTP              int
+	ServerPortHealth            int
+	ServerPortMetrics           int
+	ServerPortAdmin             int
+	ServerTLSCertFile           string
+	ServerTLSKeyFile            string
+	ServerTLSSecret             string
+	ServerTLSHostname           string
+	ServerClientCAFile          string
+	ServerTokenReview           bool
+	CertRenewalThreshold        time.Duration
+	CertCheckPeriod             time.Duration
+	ClusterCheckPeriod          time.Duration
+	ClusterCircuitBreaker       bool
+	DiscoveryRefreshPeriod      time.Duration
 	CPUProfile                  string
 	ArbitraryOptions            map[string]*ArbitraryOption
 }
@@ -97,6 +112,10 @@ func (this *Config) AddDurationOption(name string) (*ArbitraryOption, bool) {
 	return this.AddOption(name, reflect.TypeOf((*time.Duration)(nil)).Elem())
 }
 
+func (this *Config) AddFloatOption(name string) (*ArbitraryOption, bool) {
+	return this.AddOption(name, reflect.TypeOf((*float64)(nil)).Elem())
+}
+
 func (this *Config) AddBoolOption(name string) (*ArbitraryOption, bool) {
 	return this.AddOption(name, reflect.TypeOf((*bool)(nil)).Elem())
 }
@@ -106,9 +125,24 @@ func (this *Config) AddToCommand(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&this.Name, "name", "", "", "name used for controller manager")
 	cmd.PersistentFlags().StringVarP(&this.Namespace, "namespace", "", "", "namespace for lease")
 	cmd.PersistentFlags().BoolVarP(&this.OmitLease, "omit-lease", "", false, "omit lease for development")
+	cmd.PersistentFlags().BoolVarP(&this.DryRun, "dry-run", "", false, "execute write requests as server side dry-run and log the changes, without events, finalizers and CRD deployment (implies --omit-lease)")
 	cmd.PersistentFlags().StringVarP(&this.Controllers, "controllers", "c", "all", "comma separated list of controllers to start (<name>,source,target,all)")
 	cmd.PersistentFlags().StringVarP(&this.PluginDir, "plugin-dir", "", "", "directory containing go plugins")
 	cmd.PersistentFlags().IntVarP(&this.ServerPortHTTP, "server-port-http", "", 0, "HTTP server port (serving /healthz, /metrics, ...)")
+	cmd.PersistentFlags().IntVarP(&this.ServerPortHealth, "server-port-health", "", 0, "separate plain HTTP port for health endpoints (/healthz)")
+	cmd.PersistentFlags().IntVarP(&this.ServerPortMetrics, "server-port-metrics", "", 0, "separate port for metrics endpoints (/metrics)")
+	cmd.PersistentFlags().IntVarP(&this.ServerPortAdmin, "server-port-admin", "", 0, "separate port for all other endpoints")
+	cmd.PersistentFlags().StringVarP(&this.ServerTLSCertFile, "server-tls-cert-file", "", "", "certificate file for HTTPS server")
+	cmd.PersistentFlags().StringVarP(&this.ServerTLSKeyFile, "server-tls-key-file", "", "", "private key file for HTTPS server")
+	cmd.PersistentFlags().StringVarP(&this.ServerTLSSecret, "server-tls-secret", "", "", "secret (in namespace of controller manager) to maintain HTTPS server certificate")
+	cmd.PersistentFlags().StringVarP(&this.ServerTLSHostname, "server-tls-hostname", "", "", "host name for HTTPS server certificate maintained in secret (default: name of controller manager)")
+	cmd.PersistentFlags().StringVarP(&this.ServerClientCAFile, "server-client-ca-file", "", "", "CA bundle used to authenticate client certificates for non-health endpoints")
+	cmd.PersistentFlags().BoolVarP(&this.ServerTokenReview, "server-token-review", "", false, "authenticate bearer tokens for non-health endpoints with a TokenReview on the default cluster")
+	cmd.PersistentFlags().DurationVarP(&this.CertRenewalThreshold, "certificate-renewal-threshold", "", 0, "remaining validity of maintained certificates triggering a renewal (default: 7 days)")
+	cmd.PersistentFlags().DurationVarP(&this.CertCheckPeriod, "certificate-check-period", "", 0, "check period for maintained certificates (default: 1 hour)")
+	cmd.PersistentFlags().DurationVarP(&this.ClusterCheckPeriod, "cluster-check-period", "", 0, "period for checking the reachability of clusters (default: 30 seconds)")
+	cmd.PersistentFlags().BoolVarP(&this.ClusterCircuitBreaker, "cluster-circuit-breaker", "", false, "pause worker pools while the main cluster of a controller is unreachable")
+	cmd.PersistentFlags().DurationVarP(&this.DiscoveryRefreshPeriod, "discovery-refresh-period", "", 0, "period for refreshing the API discovery of clusters (default: 10 minutes)")
 	cmd.PersistentFlags().StringVarP(&this.LogLevel, "log-level", "D", "", "logrus log level")
 	cmd.PersistentFlags().StringVarP(&this.CPUProfile, "cpuprofile", "", "", "set file for cpu profiling")
 	cmd.PersistentFlags().BoolVarP(&this.NamespaceRestriction, "namespace-local-access-only", "n", false, "enable access restriction for namespace local access only (deprecated)")
@@ -44,6 +44,8 @@ func (this *ArbitraryOption) AddToCommand(cmd *cobra.Command) {
 		this.FlagSet.Int(this.Name, 0, this.Description)
 	case reflect.TypeOf((*bool)(nil)).Elem():
 		this.FlagSet.Bool(this.Name, false, this.Description)
+	case reflect.TypeOf((*float64)(nil)).Elem():
+		this.FlagSet.Float64(this.Name, 0, this.Description)
 	case reflect.TypeOf((*time.Duration)(nil)).Elem():
 		this.FlagSet.Duration(this.Name, 0, this.Description)
 	default:
@@ -106,6 +108,16 @@ func (this *ArbitraryOption) BoolValue() bool {
 	}
 	return false
 }
+func (this *ArbitraryOption) FloatValue() float64 {
+	if this.FlagSet.Changed(this.Name) || this.Default == nil {
+		v, _ := this.FlagSet.GetFloat64(this.Name)
+		return v
+	}
+	if this.Default != nil {
+		return this.defaultAsValue().(float64)
+	}
+	return 0
+}
 func (this *ArbitraryOption) DurationValue() time.Duration {
 	if this.FlagSet.Changed(this.Name) || this.Default == nil {
 		v, _ := this.FlagSet.GetDuration(this.Name)
@@ -21,6 +21,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/groups"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/mappings"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/webhook"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -29,12 +30,15 @@ type Configuration struct {
 	description    string
 	cluster_reg    cluster.Registry
 	controller_reg controller.Registry
+	webhook_reg    webhook.Registry
 }
 
 var _ cluster.RegistrationInterface = &Configuration{}
 var _ mappings.RegistrationInterface = &Configuration{}
 var _ groups.RegistrationInterface = &Configuration{}
 var _ controller.RegistrationInterface = &Configuration{}
+var _ webhook.RegistrationInterface = &Configuration{}
+var _ webhook.ConversionRegistrationInterface = &Configuration{}
 
 func Configure(name, desc string, scheme *runtime.Scheme) Configuration {
 	return Configuration{
@@ -42,12 +46,14 @@ func Configure(name, desc string, scheme *runtime.Scheme) Configuration {
 		description:    desc,
 		cluster_reg:    cluster.NewRegistry(scheme),
 		controller_reg: controller.NewRegistry(),
+		webhook_reg:    webhook.NewRegistry(),
 	}
 }
 
 func (this Configuration) ByDefault() Configuration {
 	this.cluster_reg = cluster.DefaultRegistry()
 	this.controller_reg = controller.DefaultRegistry()
+	this.webhook_reg = webhook.DefaultRegistry()
 	return this
 }
 
@@ -79,11 +85,26 @@ func (this Configuration) MustRegisterController(reg controller.Registerable, gr
 	return this.controller_reg.MustRegisterController(reg, groups...)
 }
 
+func (this Configuration) RegisterWebhook(reg webhook.Registerable) error {
+	return this.webhook_reg.RegisterWebhook(reg)
+}
+func (this Configuration) MustRegisterWebhook(reg webhook.Registerable) webhook.RegistrationInterface {
+	return this.webhook_reg.MustRegisterWebhook(reg)
+}
+
+func (this Configuration) RegisterConversion(reg webhook.ConversionRegisterable) error {
+	return this.webhook_reg.RegisterConversion(reg)
+}
+func (this Configuration) MustRegisterConversion(reg webhook.ConversionRegisterable) webhook.ConversionRegistrationInterface {
+	return this.webhook_reg.MustRegisterConversion(reg)
+}
+
 func (this Configuration) Definition() *Definition {
 	return &Definition{
 		name:            this.name,
 		description:     this.description,
 		cluster_defs:    this.cluster_reg.GetDefinitions(),
 		controller_defs: this.controller_reg.GetDefinitions(),
+		webhook_defs:    this.webhook_reg.GetDefinitions(),
 	}
 }
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package controller
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// setupCircuitBreaker pauses all pools of the controller while its main
+// cluster is unreachable. When the cluster is reachable again, the pools
+// are resumed and all watched objects are enqueued again.
+func (this *controller) setupCircuitBreaker() {
+	if !this.env.GetConfig().ClusterCircuitBreaker {
+		return
+	}
+	connectivity := this.cluster.Connectivity()
+	if connectivity == nil {
+		return
+	}
+	connectivity.Subscribe(func(cl cluster.Interface, reachable bool) {
+		if this.ctx.Err() != nil {
+			return
+		}
+		if reachable {
+			this.Infof("main cluster %s reachable again -> resume pools", cl.GetName())
+			for _, p := range this.pools {
+				p.Resume()
+				this.resync(p)
+			}
+		} else {
+			this.Warnf("main cluster %s unreachable -> pause pools", cl.GetName())
+			for _, p := range this.pools {
+				p.Pause()
+			}
+		}
+	})
+	if !connectivity.IsReachable() {
+		for _, p := range this.pools {
+			p.Pause()
+		}
+	}
+}
+
+// resync enqueues all cached objects of the resources handled by a pool.
+func (this *controller) resync(p *pool) {
+	for _, h := range this.handlers {
+		for key, info := range h.getResources() {
+			for _, ip := range info.pools {
+				if ip != p {
+					continue
+				}
+				r, err := h.GetResource(key)
+				if err != nil {
+					this.Errorf("resync of %s failed: %s", key, err)
+					break
+				}
+				list, err := r.ListCached(labels.Everything())
+				if err != nil {
+					this.Errorf("resync of %s failed: %s", key, err)
+					break
+				}
+				for _, o := range list {
+					p.EnqueueObject(o)
+				}
+				break
+			}
+		}
+	}
+}
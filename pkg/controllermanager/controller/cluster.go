@@ -18,6 +18,7 @@ package controller
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
@@ -32,6 +33,7 @@ type clusterResourceInfo struct {
 
 type ClusterHandler struct {
 	logger.LogContext
+	lock       sync.RWMutex
 	controller *controller
 	cluster    cluster.Interface
 	resources  map[ResourceKey]*clusterResourceInfo
@@ -39,13 +41,29 @@ type ClusterHandler struct {
 
 func newClusterHandler(controller *controller, cluster cluster.Interface) *ClusterHandler {
 	return &ClusterHandler{
-		controller.NewContext("cluster", cluster.GetName()),
-		controller,
-		cluster,
-		map[ResourceKey]*clusterResourceInfo{},
+		LogContext: controller.NewContext("cluster", cluster.GetName()),
+		controller: controller,
+		cluster:    cluster,
+		resources:  map[ResourceKey]*clusterResourceInfo{},
 	}
 }
 
+func (c *ClusterHandler) getResources() map[ResourceKey]*clusterResourceInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	result := map[ResourceKey]*clusterResourceInfo{}
+	for k, v := range c.resources {
+		result[k] = v
+	}
+	return result
+}
+
+func (c *ClusterHandler) getResourceInfo(key ResourceKey) *clusterResourceInfo {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.resources[key]
+}
+
 func (c *ClusterHandler) whenReady() {
 	c.controller.whenReady()
 }
@@ -62,21 +80,26 @@ func (c *ClusterHandler) GetResource(resourceKey ResourceKey) (resources.Interfa
 	return c.cluster.GetResource(resourceKey.GroupKind())
 }
 
-func (c *ClusterHandler) register(resourceKey ResourceKey, namespace string, optionsFunc resources.TweakListOptionsFunc, usedpool *pool) error {
+func (c *ClusterHandler) register(resourceKey ResourceKey, namespace string, optionsFunc resources.TweakListOptionsFunc, metadata bool, usedpool *pool) error {
+	c.lock.Lock()
 	i := c.resources[resourceKey]
 	if i == nil {
 		i = &clusterResourceInfo{[]*pool{usedpool}}
 		c.resources[resourceKey] = i
+		c.lock.Unlock()
 
-		resource, err := c.cluster.GetResource(resourceKey.GroupKind())
+		err := c.addEventHandler(resourceKey, namespace, optionsFunc, metadata)
 		if err != nil {
-			return err
-		}
-
-		if err := resource.AddSelectedEventHandler(c.GetEventHandlerFuncs(), namespace, optionsFunc); err != nil {
+			// forget the failed registration to enable a retry
+			c.lock.Lock()
+			if c.resources[resourceKey] == i {
+				delete(c.resources, resourceKey)
+			}
+			c.lock.Unlock()
 			return err
 		}
 	} else {
+		defer c.lock.Unlock()
 		for _, p := range i.pools {
 			if p == usedpool {
 				return nil
@@ -88,6 +111,22 @@ func (c *ClusterHandler) register(resourceKey ResourceKey, namespace string, opt
 	return nil
 }
 
+func (c *ClusterHandler) addEventHandler(resourceKey ResourceKey, namespace string, optionsFunc resources.TweakListOptionsFunc, metadata bool) error {
+	resource, err := c.cluster.GetResource(resourceKey.GroupKind())
+	if err != nil {
+		return err
+	}
+	if _, ok := c.controller.env.(instanceProvider); ok {
+		// instances for dynamic clusters are stopped separately, their handlers
+		// must be removed from the informers shared with other instances
+		return resource.AddRemovableEventHandler(c.controller.ctx, c.GetEventHandlerFuncs(), namespace, optionsFunc, metadata)
+	}
+	if metadata {
+		return resource.AddSelectedMetadataEventHandler(c.GetEventHandlerFuncs(), namespace, optionsFunc)
+	}
+	return resource.AddSelectedEventHandler(c.GetEventHandlerFuncs(), namespace, optionsFunc)
+}
+
 func (c *ClusterHandler) GetEventHandlerFuncs() resources.ResourceEventHandlerFuncs {
 	return resources.ResourceEventHandlerFuncs{
 		AddFunc:    c.objectAdd,
@@ -102,7 +141,7 @@ func (c *ClusterHandler) EnqueueKey(key resources.ClusterObjectKey) error {
 	//c.Infof("enqueue %s", obj.Description())
 	gk := key.GroupKind()
 	rk := NewResourceKey(gk.Group, gk.Kind)
-	i := c.resources[rk]
+	i := c.getResourceInfo(rk)
 	if i == nil {
 		c.Warnf("no resource info for type %s", rk)
 		return fmt.Errorf("cluster %q: no resource info for %s", c, rk)
@@ -120,8 +159,8 @@ func (c *ClusterHandler) EnqueueKey(key resources.ClusterObjectKey) error {
 func (c *ClusterHandler) enqueue(obj resources.Object, e func(p *pool, r resources.Object)) error {
 	c.whenReady()
 	//c.Infof("enqueue %s", obj.Description())
-	i := c.resources[GetResourceKey(obj)]
-	if i.pools == nil || len(i.pools) == 0 {
+	i := c.getResourceInfo(GetResourceKey(obj))
+	if i == nil || i.pools == nil || len(i.pools) == 0 {
 		c.Warnf("no worker pool for type %s", obj.GroupKind())
 		return fmt.Errorf("no worker pool for type %s", obj.GroupKind())
 	}
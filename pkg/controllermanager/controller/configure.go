@@ -85,6 +85,8 @@ type watchdef struct {
 	rescdef
 	reconciler string
 	pool       string
+	optional   bool
+	metadata   bool
 }
 
 type rescdef struct {
@@ -105,6 +107,12 @@ func (this *watchdef) Reconciler() string {
 func (this *watchdef) PoolName() string {
 	return this.pool
 }
+func (this *watchdef) IsOptional() bool {
+	return this.optional
+}
+func (this *watchdef) IsMetadataOnly() bool {
+	return this.metadata
+}
 
 ///////////////////////////////////////////////////////////////////////////////
 
@@ -139,6 +147,8 @@ type _Definition struct {
 	finalizerName        string
 	finalizerDomain      string
 	crds                 map[string][]*CustomResourceDefinition
+	transforms           map[string]map[ResourceKey][]resources.TransformFunc
+	indexes              map[string]map[ResourceKey]map[string]resources.Index
 	activateExplicitly   bool
 }
 
@@ -205,6 +215,31 @@ func (this *_Definition) CustomResourceDefinitions() map[string][]*CustomResourc
 	return this.crds
 }
 
+func (this *_Definition) CacheTransforms() map[string]map[ResourceKey][]resources.TransformFunc {
+	transforms := map[string]map[ResourceKey][]resources.TransformFunc{}
+	for n, m := range this.transforms {
+		transforms[n] = map[ResourceKey][]resources.TransformFunc{}
+		for k, l := range m {
+			transforms[n][k] = append([]resources.TransformFunc{}, l...)
+		}
+	}
+	return transforms
+}
+
+func (this *_Definition) CacheIndexes() map[string]map[ResourceKey]map[string]resources.Index {
+	indexes := map[string]map[ResourceKey]map[string]resources.Index{}
+	for n, m := range this.indexes {
+		indexes[n] = map[ResourceKey]map[string]resources.Index{}
+		for k, i := range m {
+			indexes[n][k] = map[string]resources.Index{}
+			for name, f := range i {
+				indexes[n][k][name] = f
+			}
+		}
+	}
+	return indexes
+}
+
 func (this *_Definition) Reconcilers() map[string]ReconcilerType {
 	types := map[string]ReconcilerType{}
 	for n, d := range this.reconcilers {
@@ -357,6 +392,51 @@ func (this Configuration) VersionedCustomResourceDefinitions(crds ...*CustomReso
 	return this
 }
 
+// CacheTransforms registers functions transforming the objects of a
+// resource of the actual cluster before they are stored in the informer
+// caches, for example resources.StripManagedFields. Because the caches are
+// shared, this affects all controllers using the cluster. Cached objects
+// are then partial and must not be used for updates.
+func (this Configuration) CacheTransforms(key ResourceKey, funcs ...resources.TransformFunc) Configuration {
+	m := map[string]map[ResourceKey][]resources.TransformFunc{}
+	for k, v := range this.settings.transforms {
+		m[k] = v
+	}
+	t := map[ResourceKey][]resources.TransformFunc{}
+	for k, v := range m[this.cluster] {
+		t[k] = v
+	}
+	t[key] = append(append([]resources.TransformFunc{}, t[key]...), funcs...)
+	m[this.cluster] = t
+	this.settings.transforms = m
+	return this
+}
+
+// CacheIndex registers a named index for the informer caches of a
+// resource of the actual cluster, for example resources.FieldPathIndex or
+// resources.AnnotationIndex. It can be queried with ListCachedByIndex.
+// Because the caches are shared, different indexes of a resource must use
+// different names among all controllers using the cluster.
+func (this Configuration) CacheIndex(key ResourceKey, name string, index resources.Index) Configuration {
+	m := map[string]map[ResourceKey]map[string]resources.Index{}
+	for k, v := range this.settings.indexes {
+		m[k] = v
+	}
+	r := map[ResourceKey]map[string]resources.Index{}
+	for k, v := range m[this.cluster] {
+		r[k] = v
+	}
+	i := map[string]resources.Index{}
+	for k, v := range r[key] {
+		i[k] = v
+	}
+	i[name] = index
+	r[key] = i
+	m[this.cluster] = r
+	this.settings.indexes = m
+	return this
+}
+
 func (this *Configuration) assureWatches() {
 	if this.settings.watches == nil {
 		this.settings.watches = map[string][]Watch{}
@@ -385,7 +465,7 @@ func (this Configuration) ReconcilerWatches(reconciler string, keys ...ResourceK
 	this.assureWatches()
 	for _, key := range keys {
 		//logger.Infof("adding watch for %q:%q to pool %q", this.cluster, key, this.pool)
-		this.settings.watches[this.cluster] = append(this.settings.watches[this.cluster], &watchdef{rescdef{key, nil}, reconciler, this.pool})
+		this.settings.watches[this.cluster] = append(this.settings.watches[this.cluster], &watchdef{rescdef{key, nil}, reconciler, this.pool, false, false})
 	}
 	return this
 }
@@ -394,7 +474,50 @@ func (this Configuration) ReconcilerSelectedWatches(reconciler string, sel Watch
 	this.assureWatches()
 	for _, key := range keys {
 		//logger.Infof("adding watch for %q:%q to pool %q", this.cluster, key, this.pool)
-		this.settings.watches[this.cluster] = append(this.settings.watches[this.cluster], &watchdef{rescdef{key, sel}, reconciler, this.pool})
+		this.settings.watches[this.cluster] = append(this.settings.watches[this.cluster], &watchdef{rescdef{key, sel}, reconciler, this.pool, false, false})
+	}
+	return this
+}
+
+// OptionalWatch adds a watch for a resource, which might not yet be
+// available in the cluster. The watch is started once the resource
+// appears. Reconcilers implementing reconcile.ResourceAvailabilityHandler
+// are notified about the start of the watch.
+func (this Configuration) OptionalWatch(group, kind string) Configuration {
+	return this.ReconcilerOptionalWatches(DEFAULT_RECONCILER, NewResourceKey(group, kind))
+}
+func (this Configuration) OptionalWatches(keys ...ResourceKey) Configuration {
+	return this.ReconcilerOptionalWatches(DEFAULT_RECONCILER, keys...)
+}
+
+func (this Configuration) ReconcilerOptionalWatches(reconciler string, keys ...ResourceKey) Configuration {
+	this.assureWatches()
+	for _, key := range keys {
+		this.settings.watches[this.cluster] = append(this.settings.watches[this.cluster], &watchdef{rescdef{key, nil}, reconciler, this.pool, true, false})
+	}
+	return this
+}
+
+// MetadataWatch adds a watch for a resource, which caches only the
+// metadata of the objects. This reduces the memory required to watch
+// resources with large objects, like secrets. The objects passed to the
+// reconciler then just contain the metadata, the complete object can be
+// read with GetFullObject.
+func (this Configuration) MetadataWatch(group, kind string) Configuration {
+	return this.ReconcilerMetadataWatches(DEFAULT_RECONCILER, NewResourceKey(group, kind))
+}
+func (this Configuration) MetadataWatches(keys ...ResourceKey) Configuration {
+	return this.ReconcilerMetadataWatches(DEFAULT_RECONCILER, keys...)
+}
+
+func (this Configuration) ReconcilerMetadataWatches(reconciler string, keys ...ResourceKey) Configuration {
+	return this.ReconcilerSelectedMetadataWatches(reconciler, nil, keys...)
+}
+
+func (this Configuration) ReconcilerSelectedMetadataWatches(reconciler string, sel WatchSelectionFunction, keys ...ResourceKey) Configuration {
+	this.assureWatches()
+	for _, key := range keys {
+		this.settings.watches[this.cluster] = append(this.settings.watches[this.cluster], &watchdef{rescdef{key, sel}, reconciler, this.pool, false, true})
 	}
 	return this
 }
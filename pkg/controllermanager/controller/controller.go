@@ -108,6 +108,9 @@ type controller struct {
 
 	handlers map[string]*ClusterHandler
 
+	lock     sync.Mutex
+	deferred []*deferredWatch
+
 	pools map[string]*pool
 }
 
@@ -122,6 +125,8 @@ func NewController(env Environment, def Definition, cmp mappings.Definition) (*c
 	if err != nil {
 		return nil, err
 	}
+	// server side apply requests of the controller use its name as field manager
+	clusters = clusters.WithFieldManager(def.GetName())
 	cluster := clusters.GetCluster(required[0])
 
 	this := &controller{
@@ -481,14 +486,103 @@ func (this *controller) Check() error {
 		return err
 	}
 
+	// usually already done by the controller manager (see RegisterCacheSettings)
+	for cname, transforms := range this.definition.CacheTransforms() {
+		cluster := this.GetCluster(cname)
+		if cluster == nil {
+			return fmt.Errorf("unknown cluster %q for %q", cname, this.GetName())
+		}
+		addCacheTransforms(this, cluster, this.definition.GetName(), transforms)
+	}
+	for cname, indexes := range this.definition.CacheIndexes() {
+		cluster := this.GetCluster(cname)
+		if cluster == nil {
+			return fmt.Errorf("unknown cluster %q for %q", cname, this.GetName())
+		}
+		err := addCacheIndexes(this, cluster, indexes)
+		if err != nil {
+			return err
+		}
+	}
+
 	// setup and check cluster handlers for all required cluster
 	for cname, watches := range this.GetDefinition().Watches() {
-		_, err := this.GetClusterHandler(cname)
+		wh, err := this.GetClusterHandler(cname)
 		if err != nil {
 			return err
 		}
 		for _, watch := range watches {
-			_, err = h.GetResource(watch.ResourceType())
+			_, err = wh.GetResource(watch.ResourceType())
+			if err != nil {
+				if watch.IsOptional() {
+					this.Infof("optional resource %q not yet available at cluster %q", watch.ResourceType(), wh)
+					continue
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RegisterCacheSettings registers the cache transformations and indexes of
+// a controller definition at the clusters used by the controller. Both must
+// be known before the informers are started, therefore the controller manager
+// registers them for all controllers before any controller is started. The
+// settings for a dynamic cluster (given by its name) are skipped, they are
+// registered when the controller instance for a discovered cluster is checked.
+func RegisterCacheSettings(logger logger.LogContext, clusters cluster.Clusters, def Definition, cmp mappings.Definition, dynamic string) error {
+	transforms := def.CacheTransforms()
+	indexes := def.CacheIndexes()
+	if len(transforms) == 0 && len(indexes) == 0 {
+		return nil
+	}
+	required := cluster.Canonical(def.RequiredClusters())
+	var main cluster.Interface
+	if real, _ := mappings.MapCluster(true, required[0], cmp); real != dynamic {
+		main = clusters.GetCluster(real)
+	}
+	lookup := func(cname string) cluster.Interface {
+		if cname == CLUSTER_MAIN || cname == required[0] {
+			return main
+		}
+		real, _ := mappings.MapCluster(false, cname, cmp)
+		if real == dynamic {
+			return nil
+		}
+		if c := clusters.GetCluster(real); c != nil {
+			return c
+		}
+		return main
+	}
+	for cname, funcs := range transforms {
+		if cl := lookup(cname); cl != nil {
+			addCacheTransforms(logger, cl, def.GetName(), funcs)
+		}
+	}
+	for cname, funcs := range indexes {
+		if cl := lookup(cname); cl != nil {
+			err := addCacheIndexes(logger, cl, funcs)
+			if err != nil {
+				return fmt.Errorf("controller %q: %s", def.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func addCacheTransforms(logger logger.LogContext, cluster cluster.Interface, name string, transforms map[ResourceKey][]resources.TransformFunc) {
+	for key, funcs := range transforms {
+		logger.Infof("using %d cache transformations for %q at cluster %q", len(funcs), key, cluster.GetName())
+		cluster.ResourceContext().AddCacheTransforms(key.GroupKind(), name, funcs...)
+	}
+}
+
+func addCacheIndexes(logger logger.LogContext, cluster cluster.Interface, indexes map[ResourceKey]map[string]resources.Index) error {
+	for key, funcs := range indexes {
+		for name, f := range funcs {
+			logger.Infof("using cache index %q for %q at cluster %q", name, key, cluster.GetName())
+			err := cluster.ResourceContext().AddCacheIndex(key.GroupKind(), name, f)
 			if err != nil {
 				return err
 			}
@@ -508,7 +602,11 @@ func (this *controller) registerWatch(h *ClusterHandler, r WatchResource, p stri
 	if r.WatchSelectionFunction() != nil {
 		ns, optionsFunc = r.WatchSelectionFunction()(this)
 	}
-	return h.register(r.ResourceType(), ns, optionsFunc, this.getPool(p))
+	metadata := false
+	if w, ok := r.(Watch); ok {
+		metadata = w.IsMetadataOnly()
+	}
+	return h.register(r.ResourceType(), ns, optionsFunc, metadata, this.getPool(p))
 }
 
 // Prepare finally prepares the controller to run
@@ -540,6 +638,11 @@ func (this *controller) Prepare() error {
 		}
 
 		for _, watch := range watches {
+			if watch.IsOptional() && !h.cluster.ResourceContext().IsAvailable(watch.ResourceType().GroupKind()) {
+				this.Infof("deferring watch for optional resources %q at cluster %q", watch.ResourceType(), h)
+				this.deferred = append(this.deferred, &deferredWatch{h, watch})
+				continue
+			}
 			this.Infof("watching additional resources %q at cluster %q", watch.ResourceType(), h)
 			this.registerWatch(h, watch, watch.PoolName())
 		}
@@ -552,6 +655,7 @@ func (this *controller) Prepare() error {
 func (this *controller) Run() {
 
 	this.ready.ready()
+	this.setupCircuitBreaker()
 	this.Infof("starting pools...")
 	for _, p := range this.pools {
 		ctxutil.SyncPointRunAndCancelOnExit(this.ctx, p.Run)
@@ -561,6 +665,7 @@ func (this *controller) Run() {
 	for _, r := range this.reconcilers {
 		r.Start()
 	}
+	this.startDeferredWatches()
 	this.Infof("controller started")
 	<-this.ctx.Done()
 	this.Info("waiting for worker pools to shutdown")
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package controller
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// deferredWatch is an optional watch waiting for its resource
+// to become available.
+type deferredWatch struct {
+	handler *ClusterHandler
+	watch   Watch
+}
+
+// startDeferredWatches checks the deferred watches whenever the discovery
+// of their cluster is refreshed, and starts them once their resources
+// are available.
+func (this *controller) startDeferredWatches() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	handled := map[*ClusterHandler]bool{}
+	for _, d := range this.deferred {
+		if !handled[d.handler] {
+			handled[d.handler] = true
+			d.handler.cluster.ResourceContext().AddDiscoveryHandler(func([]schema.GroupKind) {
+				if this.ctx.Err() == nil {
+					this.checkDeferredWatches()
+				}
+			})
+		}
+	}
+	if len(this.deferred) > 0 {
+		go this.checkDeferredWatches()
+	}
+}
+
+func (this *controller) checkDeferredWatches() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	pending := []*deferredWatch{}
+	for _, d := range this.deferred {
+		gk := d.watch.ResourceType().GroupKind()
+		if !d.handler.cluster.ResourceContext().IsAvailable(gk) {
+			pending = append(pending, d)
+			continue
+		}
+		this.Infof("optional resources %q now available at cluster %q -> start watch", d.watch.ResourceType(), d.handler)
+		err := this.registerWatch(d.handler, d.watch, d.watch.PoolName())
+		if err != nil {
+			this.Errorf("cannot watch %q at cluster %q: %s", d.watch.ResourceType(), d.handler, err)
+			pending = append(pending, d)
+			continue
+		}
+		if h, ok := this.reconcilers[d.watch.Reconciler()].(reconcile.ResourceAvailabilityHandler); ok {
+			h.ResourceAvailable(this, gk)
+		}
+	}
+	this.deferred = pending
+}
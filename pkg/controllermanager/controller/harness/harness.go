@@ -0,0 +1,415 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package harness
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster/fake"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/config"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+const SETTLE_PERIOD = 20 * time.Millisecond
+const SETTLE_TIMEOUT = 10 * time.Second
+
+// ClusterAction is a write request issued to a fake cluster.
+type ClusterAction struct {
+	Cluster string
+	fake.Action
+}
+
+func (this ClusterAction) String() string {
+	return fmt.Sprintf("%s: %s", this.Cluster, this.Action)
+}
+
+// Step describes the processing of a single key of a work queue
+// by the test harness.
+type Step struct {
+	Pool string
+	Key  string
+	// Status lists the results of all reconcilers called for the key
+	Status []reconcile.Status
+	// Events lists the events recorded while processing the key
+	Events []*corev1.Event
+	// Actions lists the other write requests issued to the clusters
+	Actions []ClusterAction
+}
+
+func (this *Step) String() string {
+	return fmt.Sprintf("%s[%s]", this.Pool, this.Key)
+}
+
+// Completed checks whether all reconcilers completed successfully.
+func (this *Step) Completed() bool {
+	for _, s := range this.Status {
+		if !s.Completed || s.Error != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type harnessEnvironment struct {
+	controller.SharedAttributes
+	ctx      context.Context
+	clusters cluster.Clusters
+	config   *config.Config
+	clock    clock.Clock
+
+	lock   sync.Mutex
+	queues map[string]*stepQueue
+}
+
+var _ controller.Environment = &harnessEnvironment{}
+
+func (this *harnessEnvironment) GetContext() context.Context {
+	return this.ctx
+}
+
+func (this *harnessEnvironment) GetClusters() cluster.Clusters {
+	return this.clusters
+}
+
+func (this *harnessEnvironment) GetCluster(name string) cluster.Interface {
+	return this.clusters.GetCluster(name)
+}
+
+func (this *harnessEnvironment) GetConfig() *config.Config {
+	return this.config
+}
+
+// NewWorkqueue provides the work queues for the pools of the controller.
+func (this *harnessEnvironment) NewWorkqueue(controller, pool string) workqueue.RateLimitingInterface {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	q := newStepQueue(this.clock)
+	this.queues[pool] = q
+	return q
+}
+
+func (this *harnessEnvironment) getQueues() map[string]*stepQueue {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	result := map[string]*stepQueue{}
+	for n, q := range this.queues {
+		result[n] = q
+	}
+	return result
+}
+
+// Harness runs a controller with its real reconcilers, pools and watches
+// on fake clusters, but instead of running worker go routines the work
+// queues are processed step by step under the control of the caller.
+// Delayed and rate limited keys are scheduled according to a fake clock,
+// which must be advanced explicitly.
+type Harness struct {
+	logger.LogContext
+	env      *harnessEnvironment
+	stepper  *controller.Stepper
+	name     string
+	clock    *clock.FakeClock
+	clusters map[string]*fake.Cluster
+	steps    []*Step
+}
+
+// New creates a harness for a controller definition. The given fake
+// clusters are used for the cluster names required by the controller,
+// missing clusters are created with an empty fake API server. Additional
+// command line flags can be used to configure the controller options.
+// The harness is stopped when the context is done.
+func New(ctx context.Context, def controller.Definition, clusters map[string]*fake.Cluster, flags ...string) (*Harness, error) {
+	var err error
+
+	lgr := logger.NewContext("harness", def.GetName())
+	this := &Harness{
+		LogContext: lgr,
+		name:       def.GetName(),
+		clock:      clock.NewFakeClock(time.Now()),
+		clusters:   map[string]*fake.Cluster{},
+	}
+	for n, c := range clusters {
+		this.clusters[n] = c
+	}
+	for _, n := range cluster.Canonical(def.RequiredClusters()) {
+		if this.clusters[n] == nil {
+			this.clusters[n], err = fake.NewCluster(ctx, n)
+			if err != nil {
+				return nil, fmt.Errorf("cannot create fake cluster %q: %s", n, err)
+			}
+		}
+	}
+	found := cluster.NewClusters()
+	for _, n := range this.clusterNames() {
+		found.Add(n, this.clusters[n].Interface)
+	}
+
+	cfg := config.NewConfig()
+	controller.AddConfigOptions(cfg, def)
+	cmd := &cobra.Command{}
+	cfg.AddToCommand(cmd)
+	if err := cmd.ParseFlags(flags); err != nil {
+		return nil, fmt.Errorf("invalid flags for controller %q: %s", def.GetName(), err)
+	}
+
+	this.env = &harnessEnvironment{
+		SharedAttributes: controller.SharedAttributes{LogContext: lgr},
+		ctx:              ctx,
+		clusters:         found,
+		config:           cfg,
+		clock:            this.clock,
+		queues:           map[string]*stepQueue{},
+	}
+
+	this.stepper, err = controller.NewStepper(this.env, def)
+	if err != nil {
+		return nil, err
+	}
+	return this, this.Settle()
+}
+
+// Controller returns the controller driven by the harness.
+func (this *Harness) Controller() controller.Interface {
+	return this.stepper.Controller()
+}
+
+// Clock returns the fake clock used to schedule delayed keys.
+func (this *Harness) Clock() *clock.FakeClock {
+	return this.clock
+}
+
+// Cluster returns the fake cluster used for a cluster name.
+func (this *Harness) Cluster(name string) *fake.Cluster {
+	return this.clusters[name]
+}
+
+// Steps returns all steps processed so far.
+func (this *Harness) Steps() []*Step {
+	return append([]*Step{}, this.steps...)
+}
+
+// Pending returns the number of keys ready for processing.
+func (this *Harness) Pending() int {
+	n := 0
+	for _, q := range this.env.getQueues() {
+		n += q.Len()
+	}
+	return n
+}
+
+// Waiting returns the number of keys scheduled for a later time.
+func (this *Harness) Waiting() int {
+	n := 0
+	for _, q := range this.env.getQueues() {
+		n += q.numWaiting()
+	}
+	return n
+}
+
+// ProcessNext processes the next key ready for processing. The pools are
+// handled in the order of their names. If no key is ready nil is returned.
+func (this *Harness) ProcessNext() (*Step, error) {
+	if err := this.Settle(); err != nil {
+		return nil, err
+	}
+	queues := this.env.getQueues()
+	for _, n := range sortedKeys(queues) {
+		if queues[n].Len() == 0 {
+			continue
+		}
+		return this.process(n, queues[n])
+	}
+	return nil, nil
+}
+
+// RunUntilQuiescent processes keys until no key is ready anymore without
+// advancing the clock. It fails if more than maxSteps keys are processed.
+func (this *Harness) RunUntilQuiescent(maxSteps int) ([]*Step, error) {
+	steps := []*Step{}
+	for {
+		step, err := this.ProcessNext()
+		if err != nil {
+			return steps, err
+		}
+		if step == nil {
+			return steps, nil
+		}
+		steps = append(steps, step)
+		if len(steps) >= maxSteps && this.Pending() > 0 {
+			return steps, fmt.Errorf("controller %q not quiescent after %d steps", this.name, maxSteps)
+		}
+	}
+}
+
+// Advance moves the clock forward and makes the delayed keys due
+// until then ready for processing.
+func (this *Harness) Advance(d time.Duration) error {
+	this.clock.Step(d)
+	return this.promote()
+}
+
+// AdvanceToNext moves the clock forward to the time the next delayed key
+// is due. It returns false if there are no delayed keys.
+func (this *Harness) AdvanceToNext() (bool, error) {
+	var next time.Time
+	found := false
+	for _, q := range this.env.getQueues() {
+		if t, ok := q.next(); ok && (!found || t.Before(next)) {
+			next = t
+			found = true
+		}
+	}
+	if !found {
+		return false, nil
+	}
+	if next.After(this.clock.Now()) {
+		this.clock.SetTime(next)
+	}
+	return true, this.promote()
+}
+
+func (this *Harness) promote() error {
+	for _, q := range this.env.getQueues() {
+		q.promote()
+	}
+	return this.Settle()
+}
+
+// Settle waits until all changes of the fake clusters have been delivered
+// to the controller and the work queues are stable.
+func (this *Harness) Settle() error {
+	timeout := time.Now().Add(SETTLE_TIMEOUT)
+	last := this.state()
+	stable := 0
+	for stable < 2 {
+		time.Sleep(SETTLE_PERIOD)
+		if this.env.ctx.Err() != nil {
+			return this.env.ctx.Err()
+		}
+		cur := this.state()
+		if cur == last && this.synced() {
+			stable++
+		} else {
+			stable = 0
+		}
+		last = cur
+		if time.Now().After(timeout) {
+			return fmt.Errorf("controller %q not settled after %s", this.name, SETTLE_TIMEOUT)
+		}
+	}
+	return nil
+}
+
+func (this *Harness) synced() bool {
+	for _, c := range this.clusters {
+		if !c.Server.IsSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+func (this *Harness) state() string {
+	s := []string{}
+	for _, n := range this.clusterNames() {
+		s = append(s, fmt.Sprintf("%s=%d", n, this.clusters[n].Server.Version()))
+	}
+	queues := this.env.getQueues()
+	for _, n := range sortedKeys(queues) {
+		s = append(s, fmt.Sprintf("%s=%d/%d", n, queues[n].Len(), queues[n].numWaiting()))
+	}
+	return strings.Join(s, ",")
+}
+
+func (this *Harness) clusterNames() []string {
+	names := []string{}
+	for n := range this.clusters {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (this *Harness) process(pool string, q *stepQueue) (*Step, error) {
+	marks := map[string]int{}
+	for n, c := range this.clusters {
+		marks[n] = len(c.Server.Actions())
+	}
+
+	step := &Step{Pool: pool}
+	if obj, ok := q.peek(); ok {
+		step.Key = fmt.Sprint(obj)
+	}
+	if err := this.stepper.Step(pool, func(status reconcile.Status) {
+		step.Status = append(step.Status, status)
+	}); err != nil {
+		return nil, err
+	}
+
+	err := this.Settle()
+	for _, n := range this.clusterNames() {
+		actions := this.clusters[n].Server.Actions()
+		if marks[n] > len(actions) {
+			marks[n] = 0
+		}
+		for _, a := range actions[marks[n]:] {
+			if a.Resource.Group == "" && a.Resource.Resource == "events" && a.Subresource == "" {
+				if e := toEvent(a); e != nil {
+					step.Events = append(step.Events, e)
+					continue
+				}
+			}
+			step.Actions = append(step.Actions, ClusterAction{Cluster: n, Action: a})
+		}
+	}
+	this.steps = append(this.steps, step)
+	return step, err
+}
+
+func toEvent(a fake.Action) *corev1.Event {
+	if a.Object == nil || a.Verb == "delete" || a.Verb == "deletecollection" {
+		return nil
+	}
+	e := &corev1.Event{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(a.Object.Object, e); err != nil {
+		return nil
+	}
+	return e
+}
+
+func sortedKeys(queues map[string]*stepQueue) []string {
+	names := []string{}
+	for n := range queues {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package harness
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// stepQueue is a rate limiting work queue used by the test harness.
+// Delayed and rate limited keys are scheduled based on a fake clock
+// and become available only if the clock is explicitly advanced.
+type stepQueue struct {
+	lock    sync.Mutex
+	cond    *sync.Cond
+	clock   clock.Clock
+	limiter workqueue.RateLimiter
+
+	queue      []interface{}
+	dirty      map[interface{}]struct{}
+	processing map[interface{}]struct{}
+	waiting    map[interface{}]time.Time
+	shutdown   bool
+}
+
+var _ workqueue.RateLimitingInterface = &stepQueue{}
+
+func newStepQueue(clock clock.Clock) *stepQueue {
+	q := &stepQueue{
+		clock:      clock,
+		limiter:    workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second),
+		dirty:      map[interface{}]struct{}{},
+		processing: map[interface{}]struct{}{},
+		waiting:    map[interface{}]time.Time{},
+	}
+	q.cond = sync.NewCond(&q.lock)
+	return q
+}
+
+func (this *stepQueue) Add(item interface{}) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.add(item)
+}
+
+func (this *stepQueue) add(item interface{}) {
+	if this.shutdown {
+		return
+	}
+	if _, ok := this.dirty[item]; ok {
+		return
+	}
+	this.dirty[item] = struct{}{}
+	if _, ok := this.processing[item]; ok {
+		return
+	}
+	this.queue = append(this.queue, item)
+	this.cond.Signal()
+}
+
+func (this *stepQueue) Len() int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return len(this.queue)
+}
+
+func (this *stepQueue) Get() (interface{}, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for len(this.queue) == 0 && !this.shutdown {
+		this.cond.Wait()
+	}
+	if len(this.queue) == 0 {
+		return nil, true
+	}
+	item := this.queue[0]
+	this.queue = this.queue[1:]
+	this.processing[item] = struct{}{}
+	delete(this.dirty, item)
+	return item, false
+}
+
+func (this *stepQueue) Done(item interface{}) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.processing, item)
+	if _, ok := this.dirty[item]; ok {
+		this.queue = append(this.queue, item)
+		this.cond.Signal()
+	}
+}
+
+func (this *stepQueue) ShutDown() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.shutdown = true
+	this.cond.Broadcast()
+}
+
+func (this *stepQueue) ShuttingDown() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.shutdown
+}
+
+func (this *stepQueue) AddAfter(item interface{}, duration time.Duration) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.shutdown {
+		return
+	}
+	if duration <= 0 {
+		this.add(item)
+		return
+	}
+	t := this.clock.Now().Add(duration)
+	if old, ok := this.waiting[item]; ok && !old.After(t) {
+		return
+	}
+	this.waiting[item] = t
+}
+
+func (this *stepQueue) AddRateLimited(item interface{}) {
+	this.AddAfter(item, this.limiter.When(item))
+}
+
+func (this *stepQueue) Forget(item interface{}) {
+	this.limiter.Forget(item)
+}
+
+func (this *stepQueue) NumRequeues(item interface{}) int {
+	return this.limiter.NumRequeues(item)
+}
+
+// promote adds the waiting keys that are due according to the clock.
+// They are added ordered by their due time and key to keep the
+// processing order reproducible.
+func (this *stepQueue) promote() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	now := this.clock.Now()
+	due := []interface{}{}
+	for item, t := range this.waiting {
+		if !t.After(now) {
+			due = append(due, item)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		ti, tj := this.waiting[due[i]], this.waiting[due[j]]
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return fmt.Sprint(due[i]) < fmt.Sprint(due[j])
+	})
+	for _, item := range due {
+		delete(this.waiting, item)
+		this.add(item)
+	}
+}
+
+// next returns the time the next waiting key is due.
+func (this *stepQueue) next() (time.Time, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	var next time.Time
+	found := false
+	for _, t := range this.waiting {
+		if !found || t.Before(next) {
+			next = t
+			found = true
+		}
+	}
+	return next, found
+}
+
+// numWaiting returns the number of keys scheduled for later processing.
+func (this *stepQueue) peek() (interface{}, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if len(this.queue) == 0 {
+		return nil, false
+	}
+	return this.queue[0], true
+}
+
+func (this *stepQueue) numWaiting() int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return len(this.waiting)
+}
@@ -76,6 +76,10 @@ type Interface interface {
 	FinalizerHandler() Finalizer
 	SetFinalizerHandler(Finalizer)
 
+	// FieldManager is the default field manager used for server side apply
+	// requests of the controller, its name.
+	FieldManager() string
+
 	EnqueueKey(key resources.ClusterObjectKey) error
 	Enqueue(object resources.Object) error
 	EnqueueRateLimited(object resources.Object) error
@@ -99,6 +103,12 @@ type Watch interface {
 	WatchResource
 	Reconciler() string
 	PoolName() string
+	// IsOptional indicates a watch for a resource, which might not yet
+	// be available. It is started once the resource appears.
+	IsOptional() bool
+	// IsMetadataOnly indicates a watch caching only the metadata
+	// of the objects.
+	IsMetadataOnly() bool
 }
 type Command interface {
 	Key() utils.Matcher
@@ -170,6 +180,8 @@ type Definition interface {
 	RequiredClusters() []string
 	RequiredControllers() []string
 	CustomResourceDefinitions() map[string][]*CustomResourceDefinition
+	CacheTransforms() map[string]map[ResourceKey][]resources.TransformFunc
+	CacheIndexes() map[string]map[ResourceKey]map[string]resources.Index
 	RequireLease() bool
 	FinalizerName() string
 	ActivateExplicitly() bool
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
@@ -99,6 +100,9 @@ type pool struct {
 	key         string
 	workqueue   workqueue.RateLimitingInterface
 	reconcilers *reconcilerMapping
+
+	lock   sync.Mutex
+	paused chan struct{}
 }
 
 func NewPool(controller *controller, name string, size int, period time.Duration) *pool {
@@ -108,8 +112,8 @@ func NewPool(controller *controller, name string, size int, period time.Duration
 		controller:  controller,
 		size:        size,
 		period:      period,
-		key:         fmt.Sprintf("controller:%s/pool:%s", controller.GetName(), name),
-		workqueue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), name),
+		key:         poolKey(controller, name),
+		workqueue:   newWorkqueue(controller, name),
 		reconcilers: newReconcilerMapping(),
 	}
 	pool.ctx, pool.LogContext = logger.WithLogger(
@@ -123,6 +127,33 @@ func NewPool(controller *controller, name string, size int, period time.Duration
 	return pool
 }
 
+// instanceProvider may be implemented by an Environment running
+// multiple instances of the same controllers, to distinguish
+// the pools of the instances.
+type instanceProvider interface {
+	GetInstanceName() string
+}
+
+func poolKey(controller *controller, name string) string {
+	if p, ok := controller.env.(instanceProvider); ok {
+		return fmt.Sprintf("controller:%s/instance:%s/pool:%s", controller.GetName(), p.GetInstanceName(), name)
+	}
+	return fmt.Sprintf("controller:%s/pool:%s", controller.GetName(), name)
+}
+
+// workqueueProvider may be implemented by an Environment to provide
+// the work queues used for the pools of its controllers.
+type workqueueProvider interface {
+	NewWorkqueue(controller, pool string) workqueue.RateLimitingInterface
+}
+
+func newWorkqueue(controller *controller, name string) workqueue.RateLimitingInterface {
+	if p, ok := controller.env.(workqueueProvider); ok {
+		return p.NewWorkqueue(controller.GetName(), name)
+	}
+	return workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), name)
+}
+
 func (p *pool) whenReady() {
 	p.controller.whenReady()
 }
@@ -179,6 +210,55 @@ func (p *pool) Run() {
 	healthz.End(p.Key())
 }
 
+// Pause pauses the processing of the pool. Keys are still queued,
+// but not handled by the workers until the pool is resumed.
+func (p *pool) Pause() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.paused == nil {
+		p.Infof("pausing pool")
+		p.paused = make(chan struct{})
+	}
+}
+
+// Resume resumes the processing of a paused pool.
+func (p *pool) Resume() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.paused != nil {
+		p.Infof("resuming pool")
+		close(p.paused)
+		p.paused = nil
+	}
+}
+
+func (p *pool) IsPaused() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.paused != nil
+}
+
+// waitWhilePaused blocks as long as the pool is paused. It returns
+// false if the pool is shut down meanwhile.
+func (p *pool) waitWhilePaused() bool {
+	for {
+		p.lock.Lock()
+		paused := p.paused
+		p.lock.Unlock()
+		if paused == nil {
+			return true
+		}
+		select {
+		case <-paused:
+		case <-p.ctx.Done():
+			return false
+		case <-time.After(tick):
+			// a paused pool is not considered to be unhealthy
+			healthz.Tick(p.Key())
+		}
+	}
+}
+
 func (p *pool) startWorker(number int, stopCh <-chan struct{}) {
 	ctxutil.SyncPointRunUntilCancelled(p.ctx, func() { newWorker(p, number).Run() })
 }
@@ -19,6 +19,7 @@ package reconcile
 import (
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"time"
 )
 
@@ -64,3 +65,10 @@ type Interface interface {
 	Deleted(logger.LogContext, resources.ClusterObjectKey) Status
 	Command(logger logger.LogContext, cmd string) Status
 }
+
+// ResourceAvailabilityHandler may be implemented by reconcilers
+// to get notified when the watch for an optional resource is
+// started, because the resource became available.
+type ResourceAvailabilityHandler interface {
+	ResourceAvailable(logger logger.LogContext, gk schema.GroupKind)
+}
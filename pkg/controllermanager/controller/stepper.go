@@ -0,0 +1,92 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/config"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/mappings"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/server/healthz"
+)
+
+// Stepper runs a controller without worker go routines. Instead, the keys
+// of its work queues are processed by explicit calls of Step. It is used
+// by test harnesses (see package harness) together with an Environment
+// providing the work queues (method NewWorkqueue(controller, pool string)).
+type Stepper struct {
+	controller *controller
+}
+
+// AddConfigOptions adds the options of the given controller
+// definitions to a configuration.
+func AddConfigOptions(cfg *config.Config, defs ...Definition) {
+	regs := Registrations{}
+	for _, def := range defs {
+		regs[def.GetName()] = def
+	}
+	(&_Definitions{definitions: regs}).ExtendConfig(cfg)
+}
+
+// NewStepper creates and starts a controller for the given definition,
+// but without starting its pools.
+func NewStepper(env Environment, def Definition) (*Stepper, error) {
+	c, err := NewController(env, def, mappings.ForController(def.GetName()).Definition())
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+	if err := c.Prepare(); err != nil {
+		return nil, err
+	}
+	c.ready.ready()
+	for _, p := range c.pools {
+		healthz.Start(p.Key(), tick)
+	}
+	go func() {
+		<-c.ctx.Done()
+		for _, p := range c.pools {
+			healthz.End(p.Key())
+		}
+	}()
+	for _, r := range c.reconcilers {
+		r.Start()
+	}
+	c.startDeferredWatches()
+	return &Stepper{controller: c}, nil
+}
+
+func (this *Stepper) Controller() Interface {
+	return this.controller
+}
+
+// Step processes the next key of the work queue of a pool. The results
+// of the called reconcilers are passed to the observer. It blocks until
+// a key is available.
+func (this *Stepper) Step(pool string, observe func(reconcile.Status)) error {
+	p := this.controller.pools[pool]
+	if p == nil {
+		return fmt.Errorf("unknown pool %q for controller %q", pool, this.controller.GetName())
+	}
+	w := newWorker(p, 0)
+	w.observe = observe
+	w.processNextWorkItem()
+	return nil
+}
@@ -39,6 +39,12 @@ func (this *controller) SetFinalizerHandler(f Finalizer) {
 	this.finalizer = f
 }
 
+// FieldManager is the default field manager used for server side apply
+// requests of the controller, its name.
+func (this *controller) FieldManager() string {
+	return this.GetName()
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type definition_field interface {
@@ -44,6 +44,7 @@ type worker struct {
 	logContext logger.LogContext
 	pool       *pool
 	workqueue  workqueue.RateLimitingInterface
+	observe    func(reconcile.Status)
 }
 
 func newWorker(p *pool, number int) *worker {
@@ -66,6 +67,13 @@ func (w *worker) Run() {
 	w.Infof("exit worker")
 }
 
+func (w *worker) observed(status reconcile.Status) reconcile.Status {
+	if w.observe != nil {
+		w.observe(status)
+	}
+	return status
+}
+
 func (w *worker) internalErr(obj interface{}, err error) bool {
 	w.Error(err)
 	w.workqueue.Forget(obj)
@@ -82,6 +90,12 @@ func (w *worker) processNextWorkItem() bool {
 	if shutdown {
 		return false
 	}
+	if w.pool.IsPaused() {
+		// requeue the key and wait for the pool to be resumed
+		w.workqueue.Add(obj)
+		w.workqueue.Done(obj)
+		return w.pool.waitWhilePaused()
+	}
 	w.Debugf("GOT: %s", obj)
 	defer w.workqueue.Done(obj)
 	defer w.Debugf("DONE %s", obj)
@@ -112,7 +126,7 @@ func (w *worker) processNextWorkItem() bool {
 		reconcilers := w.pool.getReconcilers(cmd)
 		if reconcilers != nil && len(reconcilers) > 0 {
 			for _, reconciler := range reconcilers {
-				status := reconciler.Command(w, cmd)
+				status := w.observed(reconciler.Command(w, cmd))
 				if !status.Completed {
 					ok = false
 				}
@@ -158,7 +172,7 @@ func (w *worker) processNextWorkItem() bool {
 		}
 
 		for _, reconciler := range reconcilers {
-			status := f(reconciler)
+			status := w.observed(f(reconciler))
 			if !status.Completed {
 				ok = false
 			}
@@ -32,9 +32,9 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/config"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/webhook"
 	"github.com/gardener/controller-manager-library/pkg/ctxutil"
 	"github.com/gardener/controller-manager-library/pkg/logger"
-	"github.com/gardener/controller-manager-library/pkg/server"
 )
 
 type ControllerManager struct {
@@ -50,6 +50,7 @@ type ControllerManager struct {
 	registrations controller.Registrations
 	plain_groups  map[string]StartupGroup
 	lease_groups  map[string]StartupGroup
+	dynamic       map[string]*dynamicControllers
 	//shared_options map[string]*config.ArbitraryOption
 }
 
@@ -69,6 +70,12 @@ type Controller interface {
 func NewControllerManager(ctx context.Context, def *Definition) (*ControllerManager, error) {
 	config := config.Get(ctx)
 	ctx = context.WithValue(ctx, resources.ATTR_EVENTSOURCE, def.GetName())
+	if config.DryRun {
+		// a lease cannot be acquired without writing it
+		logger.Infof("dry-run mode: write requests are only simulated (lease omitted)")
+		config.OmitLease = true
+		ctx = context.WithValue(ctx, resources.ATTR_DRYRUN, true)
+	}
 
 	for n := range def.controller_defs.Names() {
 		for _, r := range def.controller_defs.Get(n).RequiredControllers() {
@@ -159,6 +166,10 @@ func NewControllerManager(ctx context.Context, def *Definition) (*ControllerMana
 	if err != nil {
 		return nil, err
 	}
+	if def.WebhookDefinitions().Size() > 0 || config.ServerTLSSecret != "" || config.ServerTokenReview {
+		set.Add(cluster.DEFAULT)
+		set.AddSet(def.WebhookDefinitions().RequiredClusters())
+	}
 
 	lgr := logger.New()
 	clusters, err := def.ClusterDefinitions().CreateClusters(ctx, lgr, config, set)
@@ -179,6 +190,7 @@ func NewControllerManager(ctx context.Context, def *Definition) (*ControllerMana
 
 		plain_groups: map[string]StartupGroup{},
 		lease_groups: map[string]StartupGroup{},
+		dynamic:      map[string]*dynamicControllers{},
 	}
 
 	ctx = logger.Set(ctxutil.SyncContext(ctx), lgr)
@@ -210,8 +222,14 @@ func (c *ControllerManager) GetClusters() cluster.Clusters {
 func (c *ControllerManager) Run() error {
 	c.Infof("run %s\n", c.name)
 
-	if c.config.ServerPortHTTP > 0 {
-		server.Serve(c.ctx, "", c.config.ServerPortHTTP)
+	err := c.registerCacheSettings()
+	if err != nil {
+		return err
+	}
+
+	err = c.startServers()
+	if err != nil {
+		return err
 	}
 
 	for _, def := range c.registrations {
@@ -224,6 +242,19 @@ func (c *ControllerManager) Run() error {
 		if err != nil {
 			return err
 		}
+		dyn, err := c.getDynamicCluster(def, cmp)
+		if err != nil {
+			return err
+		}
+		if dyn != nil {
+			c.Infof("  instantiated per cluster discovered for dynamic cluster %q", dyn.Name())
+			d := c.getDynamicControllers(dyn)
+			d.add(def, cmp)
+			if def.RequireLease() {
+				d.lease = true
+			}
+			continue
+		}
 		cntr, err := controller.NewController(c, def, cmp)
 		if err != nil {
 			return err
@@ -236,7 +267,30 @@ func (c *ControllerManager) Run() error {
 		}
 	}
 
-	err := c.startGroups(c.plain_groups, c.lease_groups)
+	if c.definition.WebhookDefinitions().Size() > 0 {
+		c.Infof("creating webhook server")
+		server, err := webhook.NewServer(c, c.definition.WebhookDefinitions())
+		if err != nil {
+			return err
+		}
+		err = server.Start()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, d := range c.dynamic {
+		if d.host == nil {
+			return d.Check()
+		}
+		if d.lease {
+			c.getLeaseStartupGroup(d.host).Add(d)
+		} else {
+			c.getPlainStartupGroup(d.host).Add(d)
+		}
+	}
+
+	err = c.startGroups(c.plain_groups, c.lease_groups)
 	if err != nil {
 		return err
 	}
@@ -248,9 +302,33 @@ func (c *ControllerManager) Run() error {
 	return nil
 }
 
+// registerCacheSettings registers the cache transformations and indexes
+// of all controllers before any informer is started.
+func (c *ControllerManager) registerCacheSettings() error {
+	for _, def := range c.registrations {
+		cmp, err := c.definition.GetMappingsFor(def.GetName())
+		if err != nil {
+			return err
+		}
+		dyn, err := c.getDynamicCluster(def, cmp)
+		if err != nil {
+			return err
+		}
+		dynamic := ""
+		if dyn != nil {
+			dynamic = dyn.Name()
+		}
+		err = controller.RegisterCacheSettings(c, c.clusters, def, cmp, dynamic)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // checkController does all the checks that might cause startController to fail
 // after the check startController can execute without error
-func (c *ControllerManager) checkController(cntr Controller) error {
+func (c *ControllerManager) checkController(cntr Startable) error {
 	return cntr.Check()
 }
 
@@ -258,7 +336,7 @@ func (c *ControllerManager) checkController(cntr Controller) error {
 // all error conditions MUST also be checked
 // in checkController, so after a successful checkController
 // startController MUST not return an error.
-func (c *ControllerManager) startController(cntr Controller) error {
+func (c *ControllerManager) startController(cntr Startable) error {
 	err := cntr.Prepare()
 	if err != nil {
 		return err
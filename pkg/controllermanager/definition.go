@@ -22,6 +22,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/groups"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/mappings"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/webhook"
 )
 
 type Definition struct {
@@ -29,6 +30,7 @@ type Definition struct {
 	description     string
 	cluster_defs    cluster.Definitions
 	controller_defs controller.Definitions
+	webhook_defs    webhook.Definitions
 }
 
 func (this *Definition) GetName() string {
@@ -47,6 +49,10 @@ func (this *Definition) ControllerDefinitions() controller.Definitions {
 	return this.controller_defs
 }
 
+func (this *Definition) WebhookDefinitions() webhook.Definitions {
+	return this.webhook_defs
+}
+
 func (this *Definition) Groups() groups.Definitions {
 	return this.controller_defs.Groups()
 }
@@ -62,6 +68,7 @@ func (this *Definition) GetMappingsFor(name string) (mappings.Definition, error)
 func (this *Definition) ExtendConfig(cfg *config.Config) {
 	this.cluster_defs.ExtendConfig(cfg)
 	this.controller_defs.ExtendConfig(cfg)
+	this.webhook_defs.ExtendConfig(cfg)
 }
 
 func DefaultDefinition(name, desc string) *Definition {
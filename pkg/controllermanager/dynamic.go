@@ -0,0 +1,196 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package controllermanager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/mappings"
+	"github.com/gardener/controller-manager-library/pkg/ctxutil"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// dynamicControllers handles all controllers using a dynamic cluster.
+// For every cluster discovered by the dynamic source, a separate
+// instance of every controller is created, using its own pools and
+// informers. The instances are stopped when the cluster is removed.
+type dynamicControllers struct {
+	logger.LogContext
+	manager     *ControllerManager
+	definition  cluster.Definition
+	host        cluster.Interface
+	controllers []dynamicController
+	lease       bool
+
+	lock      sync.Mutex
+	instances map[string]context.Context
+}
+
+type dynamicController struct {
+	definition controller.Definition
+	mappings   mappings.Definition
+}
+
+var _ Startable = &dynamicControllers{}
+
+// getDynamicCluster determines the dynamic cluster definition used
+// by a controller, if there is any.
+func (c *ControllerManager) getDynamicCluster(def controller.Definition, cmp mappings.Definition) (cluster.Definition, error) {
+	var found cluster.Definition
+	for i, n := range cluster.Canonical(def.RequiredClusters()) {
+		real, _ := mappings.MapCluster(i == 0, n, cmp)
+		cdef := c.definition.ClusterDefinitions().Get(real)
+		if cdef == nil || !cdef.IsDynamic() {
+			continue
+		}
+		if found != nil && found.Name() != cdef.Name() {
+			return nil, fmt.Errorf("controller %q uses multiple dynamic clusters (%s, %s)", def.GetName(), found.Name(), cdef.Name())
+		}
+		found = cdef
+	}
+	return found, nil
+}
+
+func (c *ControllerManager) getDynamicControllers(def cluster.Definition) *dynamicControllers {
+	d := c.dynamic[def.Name()]
+	if d == nil {
+		d = &dynamicControllers{
+			LogContext: c.NewContext("dynamic", def.Name()),
+			manager:    c,
+			definition: def,
+			host:       c.GetCluster(def.DynamicHost()),
+			instances:  map[string]context.Context{},
+		}
+		c.dynamic[def.Name()] = d
+	}
+	return d
+}
+
+func (this *dynamicControllers) add(def controller.Definition, cmp mappings.Definition) {
+	this.controllers = append(this.controllers, dynamicController{def, cmp})
+}
+
+func (this *dynamicControllers) GetName() string {
+	return "dynamic:" + this.definition.Name()
+}
+
+func (this *dynamicControllers) Check() error {
+	if this.host == nil {
+		return fmt.Errorf("host cluster %q not found for dynamic cluster %q", this.definition.DynamicHost(), this.definition.Name())
+	}
+	return nil
+}
+
+func (this *dynamicControllers) Prepare() error {
+	return nil
+}
+
+func (this *dynamicControllers) Run() {
+	source, err := cluster.NewDynamicSource(this.manager.ctx, this, this.manager.config, this.definition, this.host, this.manager.config.Namespace)
+	if err == nil {
+		source.AddHandler(this)
+		err = source.Start()
+	}
+	if err != nil {
+		this.Errorf("cannot start dynamic cluster source: %s", err)
+		return
+	}
+	<-this.manager.ctx.Done()
+}
+
+func (this *dynamicControllers) ClusterAdded(cl cluster.Interface) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	ctx := ctxutil.CancelContext(this.manager.ctx)
+	env := &dynamicEnvironment{
+		ControllerManager: this.manager,
+		ctx:               ctx,
+		instance:          cl.GetName(),
+		clusters:          this.manager.clusters.Extend(this.definition.Name(), cl, cl.GetName()),
+	}
+
+	cntrs := []Controller{}
+	for _, d := range this.controllers {
+		this.Infof("creating controller %s for cluster %s", d.definition.GetName(), cl.GetName())
+		cntr, err := controller.NewController(env, d.definition, d.mappings)
+		if err == nil {
+			err = cntr.Check()
+		}
+		if err == nil {
+			err = cntr.Prepare()
+		}
+		if err != nil {
+			// cancelling the context removes the event handlers of the
+			// controllers already prepared
+			ctxutil.Cancel(ctx)
+			return fmt.Errorf("cannot create controller %s for cluster %s: %s", d.definition.GetName(), cl.GetName(), err)
+		}
+		cntrs = append(cntrs, cntr)
+	}
+	for _, cntr := range cntrs {
+		ctxutil.SyncPointRun(ctx, cntr.Run)
+	}
+	this.instances[cl.GetName()] = ctx
+	return nil
+}
+
+func (this *dynamicControllers) ClusterRemoved(cl cluster.Interface) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	ctx := this.instances[cl.GetName()]
+	if ctx != nil {
+		// stops the pools and removes the event handlers of the instances
+		this.Infof("stopping controllers for cluster %s", cl.GetName())
+		delete(this.instances, cl.GetName())
+		ctxutil.Cancel(ctx)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type dynamicEnvironment struct {
+	*ControllerManager
+	ctx      context.Context
+	instance string
+	clusters cluster.Clusters
+}
+
+var _ controller.Environment = &dynamicEnvironment{}
+
+func (this *dynamicEnvironment) GetContext() context.Context {
+	return this.ctx
+}
+
+// GetInstanceName distinguishes the controller instances
+// of the different dynamic clusters.
+func (this *dynamicEnvironment) GetInstanceName() string {
+	return this.instance
+}
+
+func (this *dynamicEnvironment) GetClusters() cluster.Clusters {
+	return this.clusters
+}
+
+func (this *dynamicEnvironment) GetCluster(name string) cluster.Interface {
+	return this.clusters.GetCluster(name)
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package controllermanager
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/gardener/controller-manager-library/pkg/cert"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/certmgmt"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/server"
+)
+
+// startServers starts the listeners of the HTTP server. Endpoint kinds
+// without a dedicated port are served by the general server port.
+// A dedicated health listener always uses plain HTTP without
+// authentication, to be usable for simple probes.
+func (c *ControllerManager) startServers() error {
+	cfg := c.config
+
+	ports := map[server.Kind]int{
+		server.HEALTH:  cfg.ServerPortHealth,
+		server.METRICS: cfg.ServerPortMetrics,
+		server.ADMIN:   cfg.ServerPortAdmin,
+	}
+	listeners := map[int][]server.Kind{}
+	for _, kind := range server.AllKinds {
+		port := ports[kind]
+		if port <= 0 {
+			port = cfg.ServerPortHTTP
+		}
+		if port > 0 {
+			listeners[port] = append(listeners[port], kind)
+		}
+	}
+	if len(listeners) == 0 {
+		return nil
+	}
+
+	tmpl, err := c.serverConfig()
+	if err != nil {
+		return err
+	}
+	for port, kinds := range listeners {
+		l := tmpl
+		l.Port = port
+		l.Kinds = kinds
+		name := "http"
+		if port != cfg.ServerPortHTTP {
+			name = string(kinds[0])
+			if len(kinds) == 1 && kinds[0] == server.HEALTH {
+				l.Certificates = nil
+				l.ClientCAs = nil
+				l.Authenticator = nil
+			}
+		}
+		server.ServeConfig(c.ctx, name, l)
+	}
+	return nil
+}
+
+func (c *ControllerManager) serverConfig() (server.Config, error) {
+	cfg := c.config
+	result := server.Config{}
+
+	switch {
+	case cfg.ServerTLSCertFile != "" || cfg.ServerTLSKeyFile != "":
+		if cfg.ServerTLSCertFile == "" || cfg.ServerTLSKeyFile == "" {
+			return result, fmt.Errorf("server certificate requires certificate and key file")
+		}
+		if cfg.ServerTLSSecret != "" {
+			return result, fmt.Errorf("server certificate either from files or from secret")
+		}
+		src, err := server.NewFileCertificateSource(cfg.ServerTLSCertFile, cfg.ServerTLSKeyFile)
+		if err != nil {
+			return result, err
+		}
+		result.Certificates = src
+	case cfg.ServerTLSSecret != "":
+		hostname := cfg.ServerTLSHostname
+		if hostname == "" {
+			hostname = c.name
+		}
+		access := certmgmt.NewSecret(c.GetCluster(cluster.DEFAULT), resources.NewObjectName(cfg.Namespace, cfg.ServerTLSSecret))
+		renewer := certmgmt.NewRenewer(c, "server", access, &cert.Config{
+			CommonName: c.name,
+			DNSNames:   []string{hostname},
+		}, cfg.CertRenewalThreshold, cfg.CertCheckPeriod)
+		src := server.NewUpdatableCertificateSource()
+		renewer.Subscribe(src.Update)
+		err := renewer.Start(c.ctx)
+		if err != nil {
+			return result, fmt.Errorf("cannot provide server certificate: %s", err)
+		}
+		if _, err := src.GetCertificate(nil); err != nil {
+			return result, err
+		}
+		result.Certificates = src
+	}
+
+	auths := []server.Authenticator{}
+	if cfg.ServerClientCAFile != "" {
+		if result.Certificates == nil {
+			return result, fmt.Errorf("client certificate authentication requires HTTPS")
+		}
+		data, err := ioutil.ReadFile(cfg.ServerClientCAFile)
+		if err != nil {
+			return result, fmt.Errorf("cannot read client CA file: %s", err)
+		}
+		result.ClientCAs = x509.NewCertPool()
+		if !result.ClientCAs.AppendCertsFromPEM(data) {
+			return result, fmt.Errorf("no certificates found in client CA file %q", cfg.ServerClientCAFile)
+		}
+		auths = append(auths, server.NewClientCertAuthenticator())
+	}
+	if cfg.ServerTokenReview {
+		restcfg := c.GetCluster(cluster.DEFAULT).Config()
+		auth, err := server.NewTokenReviewAuthenticator(&restcfg)
+		if err != nil {
+			return result, fmt.Errorf("cannot create token review authenticator: %s", err)
+		}
+		auths = append(auths, auth)
+	}
+	if len(auths) > 0 {
+		result.Authenticator = server.NewUnionAuthenticator(auths...)
+	}
+	return result, nil
+}
@@ -20,18 +20,27 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
 )
 
+// Startable is the part of a controller required by a startup group.
+type Startable interface {
+	GetName() string
+
+	Check() error
+	Prepare() error
+	Run()
+}
+
 type StartupGroup interface {
 	Startup() error
-	Add(c Controller)
+	Add(c Startable)
 }
 
 type startupgroup struct {
 	manager     *ControllerManager
 	cluster     cluster.Interface
-	controllers []Controller
+	controllers []Startable
 }
 
-func (this *startupgroup) Add(c Controller) {
+func (this *startupgroup) Add(c Startable) {
 	this.controllers = append(this.controllers, c)
 }
 
@@ -0,0 +1,204 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	adminreg "k8s.io/api/admissionregistration/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func init() {
+	resources.Register(adminreg.SchemeBuilder)
+}
+
+type WebhookKind string
+
+const VALIDATING = WebhookKind("validating")
+const MUTATING = WebhookKind("mutating")
+
+type Operation = adminreg.OperationType
+
+const CREATE = adminreg.Create
+const UPDATE = adminreg.Update
+const DELETE = adminreg.Delete
+const CONNECT = adminreg.Connect
+
+// AdmissionFunction handles an admission request. obj is nil for
+// DELETE requests, old is only set for UPDATE and DELETE requests.
+// Mutating webhooks may modify the data of obj, the modifications
+// are sent back to the api server as JSON patch.
+// Returning an error denies the request.
+type AdmissionFunction func(logger logger.LogContext, req *AdmissionRequest, obj, old resources.Object) error
+
+type Definition interface {
+	GetName() string
+	GetKind() WebhookKind
+	Cluster() string
+	Resources() []schema.GroupKind
+	Operations() []Operation
+	FailurePolicy() adminreg.FailurePolicyType
+	Handler() AdmissionFunction
+
+	Definition() Definition
+}
+
+type _Definition struct {
+	name          string
+	kind          WebhookKind
+	cluster       string
+	resources     []schema.GroupKind
+	operations    []Operation
+	failurePolicy adminreg.FailurePolicyType
+	handler       AdmissionFunction
+}
+
+var _ Definition = &_Definition{}
+
+func (this *_Definition) String() string {
+	return fmt.Sprintf("%s webhook %s (cluster %s, resources %v)", this.kind, this.name, this.cluster, this.resources)
+}
+
+func (this *_Definition) GetName() string {
+	return this.name
+}
+func (this *_Definition) GetKind() WebhookKind {
+	return this.kind
+}
+func (this *_Definition) Cluster() string {
+	return this.cluster
+}
+func (this *_Definition) Resources() []schema.GroupKind {
+	return append(this.resources[:0:0], this.resources...)
+}
+func (this *_Definition) Operations() []Operation {
+	if len(this.operations) == 0 {
+		return []Operation{adminreg.OperationAll}
+	}
+	return append(this.operations[:0:0], this.operations...)
+}
+func (this *_Definition) FailurePolicy() adminreg.FailurePolicyType {
+	return this.failurePolicy
+}
+func (this *_Definition) Handler() AdmissionFunction {
+	return this.handler
+}
+func (this *_Definition) Definition() Definition {
+	return this
+}
+
+func (this *_Definition) path() string {
+	if this.kind == MUTATING {
+		return "/mutate/" + this.name
+	}
+	return "/validate/" + this.name
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type Configuration struct {
+	settings _Definition
+}
+
+// Configure starts the configuration of an admission webhook.
+// By default it is a validating webhook on the default cluster for
+// all operations, rejecting requests if the webhook is not available.
+// The appropriate webhook configuration object (including the CA bundle)
+// is maintained by the webhook server of the controller manager.
+func Configure(name string) Configuration {
+	return Configuration{
+		settings: _Definition{
+			name:          name,
+			kind:          VALIDATING,
+			cluster:       cluster.DEFAULT,
+			failurePolicy: adminreg.Fail,
+		},
+	}
+}
+
+func (this Configuration) Name(name string) Configuration {
+	this.settings.name = name
+	return this
+}
+
+func (this Configuration) Validating() Configuration {
+	this.settings.kind = VALIDATING
+	return this
+}
+
+func (this Configuration) Mutating() Configuration {
+	this.settings.kind = MUTATING
+	return this
+}
+
+func (this Configuration) Cluster(name string) Configuration {
+	this.settings.cluster = name
+	return this
+}
+
+func (this Configuration) Resource(group, kind string) Configuration {
+	if group == "core" {
+		group = corev1.GroupName
+	}
+	this.settings.resources = append(this.settings.resources[:0:0], this.settings.resources...)
+	this.settings.resources = append(this.settings.resources, schema.GroupKind{Group: group, Kind: kind})
+	return this
+}
+
+func (this Configuration) Operations(ops ...Operation) Configuration {
+	this.settings.operations = append(this.settings.operations[:0:0], this.settings.operations...)
+	this.settings.operations = append(this.settings.operations, ops...)
+	return this
+}
+
+func (this Configuration) FailurePolicy(policy adminreg.FailurePolicyType) Configuration {
+	this.settings.failurePolicy = policy
+	return this
+}
+
+func (this Configuration) Handler(f AdmissionFunction) Configuration {
+	this.settings.handler = f
+	return this
+}
+
+func (this Configuration) Definition() Definition {
+	return &this.settings
+}
+
+func (this Configuration) RegisterAt(registry RegistrationInterface) error {
+	return registry.RegisterWebhook(this)
+}
+
+func (this Configuration) MustRegisterAt(registry RegistrationInterface) Configuration {
+	registry.MustRegisterWebhook(this)
+	return this
+}
+
+func (this Configuration) Register() error {
+	return registry.RegisterWebhook(this)
+}
+
+func (this Configuration) MustRegister() Configuration {
+	registry.MustRegisterWebhook(this)
+	return this
+}
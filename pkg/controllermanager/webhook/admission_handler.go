@@ -0,0 +1,156 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type admissionHandler struct {
+	logger.LogContext
+	definition Definition
+	cluster    cluster.Interface
+}
+
+func newAdmissionHandler(logger logger.LogContext, def Definition, cluster cluster.Interface) http.Handler {
+	return &admissionHandler{logger.NewContext(string(def.GetKind()), def.GetName()), def, cluster}
+}
+
+func (this *admissionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &AdmissionReview{}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, review)
+	}
+	if err != nil || review.Request == nil {
+		if err == nil {
+			err = fmt.Errorf("no admission request")
+		}
+		this.Errorf("invalid admission review: %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review.Response = this.admit(review.Request)
+	review.Request = nil
+
+	data, err := json.Marshal(review)
+	if err != nil {
+		this.Errorf("cannot marshal admission review: %s", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (this *admissionHandler) admit(req *AdmissionRequest) *AdmissionResponse {
+	resp := &AdmissionResponse{
+		UID:     req.UID,
+		Allowed: true,
+	}
+
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+	res, err := this.cluster.Resources().GetByGVK(gvk)
+	if err != nil {
+		res, err = this.cluster.Resources().GetUnstructuredByGVK(gvk)
+	}
+	if err != nil {
+		return denied(resp, http.StatusBadRequest, fmt.Errorf("unknown resource %s: %s", gvk, err))
+	}
+
+	logger := this.NewContext("object", fmt.Sprintf("%s/%s/%s", gvk.Kind, req.Namespace, req.Name))
+	obj, err := decode(res, req, req.Object.Raw)
+	if err != nil {
+		return denied(resp, http.StatusBadRequest, fmt.Errorf("invalid object: %s", err))
+	}
+	old, err := decode(res, req, req.OldObject.Raw)
+	if err != nil {
+		return denied(resp, http.StatusBadRequest, fmt.Errorf("invalid old object: %s", err))
+	}
+
+	var orig []byte
+	if obj != nil && this.definition.GetKind() == MUTATING {
+		orig, err = json.Marshal(obj.Data())
+		if err != nil {
+			return denied(resp, http.StatusInternalServerError, err)
+		}
+	}
+
+	err = this.definition.Handler()(logger, req, obj, old)
+	if err != nil {
+		logger.Infof("%s denied: %s", req.Operation, err)
+		return denied(resp, http.StatusForbidden, err)
+	}
+
+	if orig != nil {
+		modified, err := json.Marshal(obj.Data())
+		if err != nil {
+			return denied(resp, http.StatusInternalServerError, err)
+		}
+		patch, err := createJSONPatch(orig, modified)
+		if err != nil {
+			return denied(resp, http.StatusInternalServerError, err)
+		}
+		if len(patch) > 0 {
+			data, err := json.Marshal(patch)
+			if err != nil {
+				return denied(resp, http.StatusInternalServerError, err)
+			}
+			logger.Debugf("%s patched: %s", req.Operation, string(data))
+			pt := PatchTypeJSONPatch
+			resp.Patch = data
+			resp.PatchType = &pt
+		}
+	}
+	return resp
+}
+
+func decode(res resources.Interface, req *AdmissionRequest, raw []byte) (resources.Object, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	obj := res.New(resources.NewObjectName(req.Namespace, req.Name))
+	err := json.Unmarshal(raw, obj.Data())
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func denied(resp *AdmissionResponse, code int32, err error) *AdmissionResponse {
+	resp.Allowed = false
+	resp.Patch = nil
+	resp.PatchType = nil
+	resp.Result = &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    code,
+		Message: err.Error(),
+	}
+	return resp
+}
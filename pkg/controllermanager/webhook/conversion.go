@@ -0,0 +1,185 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ConversionFunction converts an object of a custom resource
+// into another version. The framework sets the apiVersion of the
+// result, so the function only has to care about the content.
+type ConversionFunction func(logger logger.LogContext, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
+
+type ConversionKey struct {
+	GroupKind schema.GroupKind
+	From      string
+	To        string
+}
+
+func (this ConversionKey) String() string {
+	return fmt.Sprintf("%s: %s->%s", this.GroupKind, this.From, this.To)
+}
+
+type ConversionDefinition interface {
+	GroupKind() schema.GroupKind
+	Cluster() string
+	Conversions() map[ConversionKey]ConversionFunction
+
+	Definition() ConversionDefinition
+}
+
+type _ConversionDefinition struct {
+	groupKind   schema.GroupKind
+	cluster     string
+	conversions map[ConversionKey]ConversionFunction
+}
+
+var _ ConversionDefinition = &_ConversionDefinition{}
+
+func (this *_ConversionDefinition) GroupKind() schema.GroupKind {
+	return this.groupKind
+}
+func (this *_ConversionDefinition) Cluster() string {
+	return this.cluster
+}
+func (this *_ConversionDefinition) Conversions() map[ConversionKey]ConversionFunction {
+	conversions := map[ConversionKey]ConversionFunction{}
+	for k, f := range this.conversions {
+		conversions[k] = f
+	}
+	return conversions
+}
+func (this *_ConversionDefinition) Definition() ConversionDefinition {
+	return this
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type ConversionConfiguration struct {
+	settings _ConversionDefinition
+}
+
+// ConfigureConversion starts the configuration of conversion functions
+// for a custom resource with multiple versions.
+// The CRD of the resource is expected on the default cluster, if not
+// configured otherwise. Its webhook client config (including the CA bundle)
+// is maintained by the webhook server of the controller manager.
+func ConfigureConversion(group, kind string) ConversionConfiguration {
+	if group == "core" {
+		group = corev1.GroupName
+	}
+	return ConversionConfiguration{
+		settings: _ConversionDefinition{
+			groupKind:   schema.GroupKind{Group: group, Kind: kind},
+			cluster:     cluster.DEFAULT,
+			conversions: map[ConversionKey]ConversionFunction{},
+		},
+	}
+}
+
+func (this ConversionConfiguration) Cluster(name string) ConversionConfiguration {
+	this.settings.cluster = name
+	return this
+}
+
+func (this ConversionConfiguration) Conversion(from, to string, f ConversionFunction) ConversionConfiguration {
+	conversions := map[ConversionKey]ConversionFunction{}
+	for k, v := range this.settings.conversions {
+		conversions[k] = v
+	}
+	conversions[ConversionKey{this.settings.groupKind, from, to}] = f
+	this.settings.conversions = conversions
+	return this
+}
+
+func (this ConversionConfiguration) Definition() ConversionDefinition {
+	return &this.settings
+}
+
+func (this ConversionConfiguration) RegisterAt(registry ConversionRegistrationInterface) error {
+	return registry.RegisterConversion(this)
+}
+
+func (this ConversionConfiguration) MustRegisterAt(registry ConversionRegistrationInterface) ConversionConfiguration {
+	registry.MustRegisterConversion(this)
+	return this
+}
+
+func (this ConversionConfiguration) Register() error {
+	return registry.RegisterConversion(this)
+}
+
+func (this ConversionConfiguration) MustRegister() ConversionConfiguration {
+	registry.MustRegisterConversion(this)
+	return this
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type conversions map[ConversionKey]ConversionFunction
+
+// lookup determines the conversion steps required to convert an
+// object of the given group kind from one version into another one.
+// If there is no direct conversion, a path via one intermediate
+// (hub) version is searched.
+func (this conversions) lookup(gk schema.GroupKind, from, to string) []ConversionKey {
+	key := ConversionKey{gk, from, to}
+	if this[key] != nil {
+		return []ConversionKey{key}
+	}
+	for k := range this {
+		if k.GroupKind == gk && k.From == from {
+			next := ConversionKey{gk, k.To, to}
+			if this[next] != nil {
+				return []ConversionKey{k, next}
+			}
+		}
+	}
+	return nil
+}
+
+func (this conversions) convert(logger logger.LogContext, obj *unstructured.Unstructured, to schema.GroupVersion) (*unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	if gvk.Group != to.Group {
+		return nil, fmt.Errorf("cannot convert %s to different group %q", gvk, to.Group)
+	}
+	if gvk.Version == to.Version {
+		return obj, nil
+	}
+	steps := this.lookup(gvk.GroupKind(), gvk.Version, to.Version)
+	if steps == nil {
+		return nil, fmt.Errorf("no conversion found for %s from %s to %s", gvk.GroupKind(), gvk.Version, to.Version)
+	}
+	var err error
+	for _, step := range steps {
+		logger.Debugf("converting %s/%s: %s", obj.GetNamespace(), obj.GetName(), step)
+		obj, err = this[step](logger, obj.DeepCopy())
+		if err != nil {
+			return nil, fmt.Errorf("conversion %s failed: %s", step, err)
+		}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: gvk.Group, Version: step.To, Kind: gvk.Kind})
+	}
+	return obj, nil
+}
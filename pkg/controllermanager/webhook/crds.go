@@ -0,0 +1,98 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// clientConfig provides the webhook client config of a CRD as unstructured
+// value, because the service port is not supported by the vendored API types.
+func (this *Server) clientConfig(path string, caBundle []byte) map[string]interface{} {
+	cfg := map[string]interface{}{
+		"caBundle": base64.StdEncoding.EncodeToString(caBundle),
+	}
+	if this.hostname != "" {
+		cfg["url"] = fmt.Sprintf("https://%s:%d%s", this.hostname, this.port, path)
+		return cfg
+	}
+	cfg["service"] = map[string]interface{}{
+		"namespace": this.serviceNamespace,
+		"name":      this.service,
+		"path":      path,
+		"port":      int64(this.servicePort),
+	}
+	return cfg
+}
+
+func (this *Server) updateCRDs(caBundle []byte) error {
+	for gk, def := range this.definitions.GetConversions() {
+		c := this.env.GetClusters().GetCluster(def.Cluster())
+		err := this.updateCRD(c, gk, caBundle)
+		if err != nil {
+			return fmt.Errorf("cannot update conversion webhook for %s: %s", gk, err)
+		}
+	}
+	return nil
+}
+
+// updateCRD maintains the conversion settings of the CRD for the
+// given group kind. The CRD name is derived from the resource
+// information of the cluster, so the CRD must already be deployed.
+func (this *Server) updateCRD(c cluster.Interface, gk schema.GroupKind, caBundle []byte) error {
+	info, err := c.ResourceContext().GetPreferred(gk)
+	if err != nil {
+		return err
+	}
+	name := info.Name() + "." + gk.Group
+
+	r, err := c.Resources().GetUnstructuredByGVK(apiext.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	if err != nil {
+		return err
+	}
+	obj, err := r.Get_(resources.NewObjectName(name))
+	if err != nil {
+		return err
+	}
+	clientConfig := this.clientConfig(CONVERSION_PATH, caBundle)
+	mod, err := obj.Modify(func(data resources.ObjectData) (bool, error) {
+		crd := data.(*unstructured.Unstructured).Object
+		strategy, _, _ := unstructured.NestedString(crd, "spec", "conversion", "strategy")
+		old, _, _ := unstructured.NestedMap(crd, "spec", "conversion", "webhookClientConfig")
+		if strategy == string(apiext.WebhookConverter) && reflect.DeepEqual(old, clientConfig) {
+			return false, nil
+		}
+		err := unstructured.SetNestedField(crd, string(apiext.WebhookConverter), "spec", "conversion", "strategy")
+		if err != nil {
+			return false, err
+		}
+		return true, unstructured.SetNestedMap(crd, clientConfig, "spec", "conversion", "webhookClientConfig")
+	})
+	if mod {
+		this.Infof("conversion webhook for CRD %s updated in cluster %s", name, c.GetName())
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/config"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+const PORT_OPTION = "webhook-server-port"
+const SERVICE_OPTION = "webhook-service"
+const SERVICE_NAMESPACE_OPTION = "webhook-service-namespace"
+const SERVICE_PORT_OPTION = "webhook-service-port"
+const HOSTNAME_OPTION = "webhook-hostname"
+const SECRET_OPTION = "webhook-secret"
+
+const DEFAULT_PORT = 8443
+const DEFAULT_SERVICE_PORT = 443
+
+type Definitions interface {
+	Size() int
+	Get(name string) Definition
+	GetWebhooks() Registrations
+	GetConversions() ConversionRegistrations
+	RequiredClusters() utils.StringSet
+	ExtendConfig(cfg *config.Config)
+}
+
+var _ Definitions = &_Definitions{}
+
+func (this *_Definitions) ExtendConfig(cfg *config.Config) {
+	if this.Size() == 0 {
+		return
+	}
+
+	opt, _ := cfg.AddIntOption(PORT_OPTION)
+	opt.Description = "port of the HTTPS server serving the webhooks"
+	opt.Default = DEFAULT_PORT
+
+	opt, _ = cfg.AddStringOption(SERVICE_OPTION)
+	opt.Description = "name of the service used to access the webhook server"
+
+	opt, _ = cfg.AddStringOption(SERVICE_NAMESPACE_OPTION)
+	opt.Description = "namespace of the webhook service (default: namespace of the controller manager)"
+
+	opt, _ = cfg.AddIntOption(SERVICE_PORT_OPTION)
+	opt.Description = "port of the webhook service"
+	opt.Default = DEFAULT_SERVICE_PORT
+
+	opt, _ = cfg.AddStringOption(HOSTNAME_OPTION)
+	opt.Description = "host name used to access the webhook server directly, instead of a service"
+
+	opt, _ = cfg.AddStringOption(SECRET_OPTION)
+	opt.Description = "name of the secret used to store the webhook server certificates (default: <name>-webhook)"
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const CONVERSION_PATH = "/convert"
+
+type conversionHandler struct {
+	logger.LogContext
+	conversions conversions
+}
+
+func newConversionHandler(logger logger.LogContext, defs *_Definitions) http.Handler {
+	return &conversionHandler{logger.NewContext("webhook", "conversion"), defs.conversionFunctions()}
+}
+
+func (this *conversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &apiext.ConversionReview{}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(body, review)
+	}
+	if err != nil || review.Request == nil {
+		if err == nil {
+			err = fmt.Errorf("no conversion request")
+		}
+		this.Errorf("invalid conversion review: %s", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	review.Response = this.convert(review.Request)
+	review.Request = nil
+
+	data, err := json.Marshal(review)
+	if err != nil {
+		this.Errorf("cannot marshal conversion review: %s", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (this *conversionHandler) convert(req *apiext.ConversionRequest) *apiext.ConversionResponse {
+	resp := &apiext.ConversionResponse{
+		UID: req.UID,
+		Result: metav1.Status{
+			Status: metav1.StatusSuccess,
+		},
+	}
+
+	gv, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		return failed(resp, fmt.Errorf("invalid desired api version %q: %s", req.DesiredAPIVersion, err))
+	}
+
+	for _, raw := range req.Objects {
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal(raw.Raw, obj); err != nil {
+			return failed(resp, fmt.Errorf("invalid object: %s", err))
+		}
+		converted, err := this.conversions.convert(this, obj, gv)
+		if err != nil {
+			this.Errorf("conversion of %s %s/%s failed: %s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName(), err)
+			return failed(resp, err)
+		}
+		data, err := json.Marshal(converted)
+		if err != nil {
+			return failed(resp, err)
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: data})
+	}
+	return resp
+}
+
+func failed(resp *apiext.ConversionResponse, err error) *apiext.ConversionResponse {
+	resp.ConvertedObjects = nil
+	resp.Result = metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: err.Error(),
+	}
+	return resp
+}
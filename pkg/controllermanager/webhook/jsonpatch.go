@@ -0,0 +1,85 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// createJSONPatch creates an RFC 6902 JSON patch describing the
+// modifications from one JSON document to another one.
+// Lists are always replaced as a whole.
+func createJSONPatch(orig, modified []byte) ([]patchOperation, error) {
+	var o, m interface{}
+	if err := json.Unmarshal(orig, &o); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(modified, &m); err != nil {
+		return nil, err
+	}
+	return diffJSON(nil, "", o, m), nil
+}
+
+func diffJSON(ops []patchOperation, path string, o, m interface{}) []patchOperation {
+	om, ok1 := o.(map[string]interface{})
+	mm, ok2 := m.(map[string]interface{})
+	if !ok1 || !ok2 {
+		if !reflect.DeepEqual(o, m) {
+			ops = append(ops, patchOperation{Op: "replace", Path: path, Value: m})
+		}
+		return ops
+	}
+
+	keys := []string{}
+	for k := range om {
+		keys = append(keys, k)
+	}
+	for k := range mm {
+		if _, ok := om[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := path + "/" + escapePointer(k)
+		ov, inOrig := om[k]
+		mv, inModified := mm[k]
+		switch {
+		case !inModified:
+			ops = append(ops, patchOperation{Op: "remove", Path: p})
+		case !inOrig:
+			ops = append(ops, patchOperation{Op: "add", Path: p, Value: mv})
+		default:
+			ops = diffJSON(ops, p, ov, mv)
+		}
+	}
+	return ops
+}
+
+func escapePointer(s string) string {
+	return strings.Replace(strings.Replace(s, "~", "~0", -1), "/", "~1", -1)
+}
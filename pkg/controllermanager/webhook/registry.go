@@ -0,0 +1,249 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// webhook definitions
+///////////////////////////////////////////////////////////////////////////////
+
+type ConversionRegistrations map[schema.GroupKind]ConversionDefinition
+
+type ConversionRegisterable interface {
+	Definition() ConversionDefinition
+}
+
+type ConversionRegistrationInterface interface {
+	RegisterConversion(ConversionRegisterable) error
+	MustRegisterConversion(ConversionRegisterable) ConversionRegistrationInterface
+}
+
+type Registrations map[string]Definition
+
+type Registerable interface {
+	Definition() Definition
+}
+
+type RegistrationInterface interface {
+	RegisterWebhook(Registerable) error
+	MustRegisterWebhook(Registerable) RegistrationInterface
+}
+
+type Registry interface {
+	RegistrationInterface
+	ConversionRegistrationInterface
+	GetDefinitions() Definitions
+}
+
+type _Definitions struct {
+	lock        sync.RWMutex
+	webhooks    Registrations
+	conversions ConversionRegistrations
+}
+
+type _Registry struct {
+	*_Definitions
+}
+
+var _ Registry = &_Registry{}
+
+func NewRegistry() Registry {
+	return &_Registry{_Definitions: &_Definitions{webhooks: Registrations{}, conversions: ConversionRegistrations{}}}
+}
+
+func DefaultDefinitions() Definitions {
+	return registry.GetDefinitions()
+}
+
+func DefaultRegistry() Registry {
+	return registry
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *_Registry) RegisterWebhook(reg Registerable) error {
+	def := reg.Definition()
+	if def == nil {
+		return fmt.Errorf("no definition found")
+	}
+	if def.Handler() == nil {
+		return fmt.Errorf("no handler for webhook %q", def.GetName())
+	}
+	if len(def.Resources()) == 0 {
+		return fmt.Errorf("no resources for webhook %q", def.GetName())
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.webhooks[def.GetName()] != nil {
+		return fmt.Errorf("multiple registration of webhook %q", def.GetName())
+	}
+	logger.Infof("Registering %s webhook %s", def.GetKind(), def.GetName())
+	this.webhooks[def.GetName()] = def
+	return nil
+}
+
+func (this *_Registry) MustRegisterWebhook(reg Registerable) RegistrationInterface {
+	err := this.RegisterWebhook(reg)
+	if err != nil {
+		panic(err)
+	}
+	return this
+}
+
+func (this *_Registry) RegisterConversion(reg ConversionRegisterable) error {
+	def := reg.Definition()
+	if def == nil {
+		return fmt.Errorf("no definition found")
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if old := this.conversions[def.GroupKind()]; old != nil {
+		if old.Cluster() != def.Cluster() {
+			return fmt.Errorf("conversions for %s registered for different clusters (%q and %q)", def.GroupKind(), old.Cluster(), def.Cluster())
+		}
+		merged := &_ConversionDefinition{def.GroupKind(), def.Cluster(), old.Conversions()}
+		for k, f := range def.Conversions() {
+			if merged.conversions[k] != nil {
+				return fmt.Errorf("multiple registration of conversion %s", k)
+			}
+			merged.conversions[k] = f
+		}
+		def = merged
+	}
+	logger.Infof("Registering conversions for %s", def.GroupKind())
+	this.conversions[def.GroupKind()] = def
+	return nil
+}
+
+func (this *_Registry) MustRegisterConversion(reg ConversionRegisterable) ConversionRegistrationInterface {
+	err := this.RegisterConversion(reg)
+	if err != nil {
+		panic(err)
+	}
+	return this
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *_Registry) GetDefinitions() Definitions {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	webhooks := Registrations{}
+	for k, v := range this.webhooks {
+		webhooks[k] = v
+	}
+	defs := ConversionRegistrations{}
+	for k, v := range this.conversions {
+		defs[k] = v
+	}
+	return &_Definitions{webhooks: webhooks, conversions: defs}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+var registry = NewRegistry()
+
+func RegisterWebhook(reg Registerable) error {
+	return registry.RegisterWebhook(reg)
+}
+
+func MustRegisterWebhook(reg Registerable) RegistrationInterface {
+	return registry.MustRegisterWebhook(reg)
+}
+
+func RegisterConversion(reg ConversionRegisterable) error {
+	return registry.RegisterConversion(reg)
+}
+
+func MustRegisterConversion(reg ConversionRegisterable) ConversionRegistrationInterface {
+	return registry.MustRegisterConversion(reg)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (this *_Definitions) Size() int {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return len(this.webhooks) + len(this.conversions)
+}
+
+func (this *_Definitions) Get(name string) Definition {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.webhooks[name]
+}
+
+func (this *_Definitions) GetWebhooks() Registrations {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	defs := Registrations{}
+	for k, v := range this.webhooks {
+		defs[k] = v
+	}
+	return defs
+}
+
+func (this *_Definitions) GetConversions() ConversionRegistrations {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	defs := ConversionRegistrations{}
+	for k, v := range this.conversions {
+		defs[k] = v
+	}
+	return defs
+}
+
+func (this *_Definitions) RequiredClusters() utils.StringSet {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	clusters := utils.StringSet{}
+	for _, def := range this.webhooks {
+		clusters.Add(def.Cluster())
+	}
+	for _, def := range this.conversions {
+		clusters.Add(def.Cluster())
+	}
+	return clusters
+}
+
+func (this *_Definitions) conversionFunctions() conversions {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	funcs := conversions{}
+	for _, def := range this.conversions {
+		for k, f := range def.Conversions() {
+			funcs[k] = f
+		}
+	}
+	return funcs
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/cert"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/certmgmt"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/config"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/server"
+)
+
+type Environment interface {
+	GetName() string
+	GetContext() context.Context
+	GetConfig() *config.Config
+	GetClusters() cluster.Clusters
+}
+
+// Server serves all webhooks registered for a controller manager
+// as server.WEBHOOK endpoints on a dedicated HTTPS listener. Its certificate
+// is maintained with the certmgmt package and stored in a secret on the
+// default cluster.
+type Server struct {
+	logger.LogContext
+	env         Environment
+	definitions *_Definitions
+
+	port             int
+	service          string
+	serviceNamespace string
+	servicePort      int
+	hostname         string
+	access           certmgmt.CertificateAccess
+
+	certificates server.UpdatableCertificateSource
+
+	lock     sync.RWMutex
+	certInfo cert.CertificateInfo
+}
+
+func NewServer(env Environment, defs Definitions) (*Server, error) {
+	cfg := env.GetConfig()
+	this := &Server{
+		LogContext:   logger.NewContext("webhook", "server"),
+		env:          env,
+		definitions:  defs.(*_Definitions),
+		certificates: server.NewUpdatableCertificateSource(),
+	}
+
+	if opt := cfg.GetOption(PORT_OPTION); opt != nil {
+		this.port = opt.IntValue()
+	}
+	if this.port <= 0 {
+		return nil, fmt.Errorf("no webhook server port configured")
+	}
+	if opt := cfg.GetOption(SERVICE_OPTION); opt != nil {
+		this.service = opt.StringValue()
+	}
+	if opt := cfg.GetOption(SERVICE_NAMESPACE_OPTION); opt != nil {
+		this.serviceNamespace = opt.StringValue()
+	}
+	if this.serviceNamespace == "" {
+		this.serviceNamespace = cfg.Namespace
+	}
+	this.servicePort = DEFAULT_SERVICE_PORT
+	if opt := cfg.GetOption(SERVICE_PORT_OPTION); opt != nil && opt.IntValue() > 0 {
+		this.servicePort = opt.IntValue()
+	}
+	if opt := cfg.GetOption(HOSTNAME_OPTION); opt != nil {
+		this.hostname = opt.StringValue()
+	}
+	if this.service == "" && this.hostname == "" {
+		return nil, fmt.Errorf("webhook server requires a service (--%s) or a host name (--%s)", SERVICE_OPTION, HOSTNAME_OPTION)
+	}
+
+	secret := ""
+	if opt := cfg.GetOption(SECRET_OPTION); opt != nil {
+		secret = opt.StringValue()
+	}
+	if secret == "" {
+		secret = env.GetName() + "-webhook"
+	}
+	c := env.GetClusters().GetCluster(cluster.DEFAULT)
+	if c == nil {
+		return nil, fmt.Errorf("no default cluster for webhook server certificates")
+	}
+	this.access = certmgmt.NewSecret(c, resources.NewObjectName(cfg.Namespace, secret))
+
+	for _, n := range this.definitions.RequiredClusters().AsArray() {
+		if env.GetClusters().GetCluster(n) == nil {
+			return nil, fmt.Errorf("cluster %q required for webhooks not found", n)
+		}
+	}
+
+	if len(this.definitions.GetConversions()) > 0 {
+		server.RegisterHandlerFor(server.WEBHOOK, CONVERSION_PATH, newConversionHandler(this, this.definitions))
+	}
+	for _, def := range this.definitions.GetWebhooks() {
+		c := env.GetClusters().GetCluster(def.Cluster())
+		server.RegisterHandlerFor(server.WEBHOOK, def.(*_Definition).path(), newAdmissionHandler(this, def, c))
+	}
+	return this, nil
+}
+
+// DNSName returns the host name used for the certificate of
+// the webhook server.
+func (this *Server) DNSName() string {
+	if this.hostname != "" {
+		return this.hostname
+	}
+	return fmt.Sprintf("%s.%s.svc", this.service, this.serviceNamespace)
+}
+
+func (this *Server) GetCertificateInfo() cert.CertificateInfo {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.certInfo
+}
+
+// UpdateCertificate propagates the CA bundle of a new server certificate
+// to all registered webhook configurations.
+func (this *Server) UpdateCertificate(info cert.CertificateInfo) error {
+	this.lock.Lock()
+	this.certInfo = info
+	this.lock.Unlock()
+
+	err := this.updateWebhookConfigurations(info.CACert())
+	if err != nil {
+		return err
+	}
+	return this.updateCRDs(info.CACert())
+}
+
+// Start starts the renewal of the server certificate, which updates
+// the served certificate and propagates the CA bundle, and finally starts
+// the HTTPS listener for the webhook endpoints in the background.
+func (this *Server) Start() error {
+	ctx := this.env.GetContext()
+	cfg := this.env.GetConfig()
+	renewer := certmgmt.NewRenewer(this, "webhook", this.access, &cert.Config{
+		CommonName:   "client:" + this.env.GetName(),
+		CACommonName: "webhook-cert-ca:" + this.env.GetName(),
+		DNSNames:     []string{this.DNSName()},
+	}, cfg.CertRenewalThreshold, cfg.CertCheckPeriod)
+	renewer.Subscribe(this.certificates.Update)
+	renewer.Subscribe(this.UpdateCertificate)
+	err := renewer.Start(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := this.certificates.GetCertificate(nil); err != nil {
+		return err
+	}
+
+	this.Infof("serving webhooks for %s", this.DNSName())
+	server.ServeConfig(ctx, "webhook", server.Config{
+		Port:         this.port,
+		Kinds:        []server.Kind{server.WEBHOOK},
+		Certificates: this.certificates,
+	})
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/cluster"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	adminreg "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (this *Server) admissionClientConfig(path string, caBundle []byte) adminreg.WebhookClientConfig {
+	if this.hostname != "" {
+		url := fmt.Sprintf("https://%s:%d%s", this.hostname, this.port, path)
+		return adminreg.WebhookClientConfig{URL: &url, CABundle: caBundle}
+	}
+	port := int32(this.servicePort)
+	return adminreg.WebhookClientConfig{
+		Service: &adminreg.ServiceReference{
+			Namespace: this.serviceNamespace,
+			Name:      this.service,
+			Path:      &path,
+			Port:      &port,
+		},
+		CABundle: caBundle,
+	}
+}
+
+// qualifiedName provides the name of a webhook used in the webhook
+// configuration objects. The api server requires names with at least
+// three segments.
+func (this *Server) qualifiedName(def Definition) string {
+	name := def.GetName()
+	if strings.Count(name, ".") >= 2 {
+		return name
+	}
+	return fmt.Sprintf("%s.%s.webhook", name, this.env.GetName())
+}
+
+func (this *Server) rules(c cluster.Interface, def Definition) ([]adminreg.RuleWithOperations, error) {
+	scope := adminreg.AllScopes
+	rules := []adminreg.RuleWithOperations{}
+	for _, gk := range def.Resources() {
+		info, err := c.ResourceContext().GetPreferred(gk)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, adminreg.RuleWithOperations{
+			Operations: def.Operations(),
+			Rule: adminreg.Rule{
+				APIGroups:   []string{gk.Group},
+				APIVersions: []string{"*"},
+				Resources:   []string{info.Name()},
+				Scope:       &scope,
+			},
+		})
+	}
+	return rules, nil
+}
+
+// The webhooks are created with the defaults set by the api server,
+// to detect required updates by simple comparison.
+var (
+	matchPolicy        = adminreg.Exact
+	sideEffects        = adminreg.SideEffectClassNone
+	reinvocationPolicy = adminreg.NeverReinvocationPolicy
+	timeoutSeconds     = int32(30)
+)
+
+// updateWebhookConfigurations maintains the validating and mutating
+// webhook configuration objects of all clusters used by admission
+// webhooks. They are named like the controller manager and contain
+// exactly the webhooks registered for the cluster.
+func (this *Server) updateWebhookConfigurations(caBundle []byte) error {
+	byCluster := map[string][]Definition{}
+	for _, def := range this.definitions.GetWebhooks() {
+		byCluster[def.Cluster()] = append(byCluster[def.Cluster()], def)
+	}
+
+	for name, defs := range byCluster {
+		sort.Slice(defs, func(i, j int) bool { return defs[i].GetName() < defs[j].GetName() })
+		c := this.env.GetClusters().GetCluster(name)
+		validating := []adminreg.ValidatingWebhook{}
+		mutating := []adminreg.MutatingWebhook{}
+		for _, def := range defs {
+			rules, err := this.rules(c, def)
+			if err != nil {
+				return fmt.Errorf("cannot determine rules for webhook %q: %s", def.GetName(), err)
+			}
+			policy := def.FailurePolicy()
+			clientConfig := this.admissionClientConfig(def.(*_Definition).path(), caBundle)
+			switch def.GetKind() {
+			case VALIDATING:
+				validating = append(validating, adminreg.ValidatingWebhook{
+					Name:                    this.qualifiedName(def),
+					ClientConfig:            clientConfig,
+					Rules:                   rules,
+					FailurePolicy:           &policy,
+					MatchPolicy:             &matchPolicy,
+					NamespaceSelector:       &metav1.LabelSelector{},
+					ObjectSelector:          &metav1.LabelSelector{},
+					SideEffects:             &sideEffects,
+					TimeoutSeconds:          &timeoutSeconds,
+					AdmissionReviewVersions: []string{ADMISSION_REVIEW_VERSION},
+				})
+			case MUTATING:
+				mutating = append(mutating, adminreg.MutatingWebhook{
+					Name:                    this.qualifiedName(def),
+					ClientConfig:            clientConfig,
+					Rules:                   rules,
+					FailurePolicy:           &policy,
+					MatchPolicy:             &matchPolicy,
+					NamespaceSelector:       &metav1.LabelSelector{},
+					ObjectSelector:          &metav1.LabelSelector{},
+					SideEffects:             &sideEffects,
+					TimeoutSeconds:          &timeoutSeconds,
+					AdmissionReviewVersions: []string{ADMISSION_REVIEW_VERSION},
+					ReinvocationPolicy:      &reinvocationPolicy,
+				})
+			}
+		}
+		if len(validating) > 0 {
+			err := this.updateWebhookConfiguration(c, &adminreg.ValidatingWebhookConfiguration{}, validating)
+			if err != nil {
+				return fmt.Errorf("cannot update validating webhook configuration in cluster %s: %s", c.GetName(), err)
+			}
+		}
+		if len(mutating) > 0 {
+			err := this.updateWebhookConfiguration(c, &adminreg.MutatingWebhookConfiguration{}, mutating)
+			if err != nil {
+				return fmt.Errorf("cannot update mutating webhook configuration in cluster %s: %s", c.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func (this *Server) updateWebhookConfiguration(c cluster.Interface, data resources.ObjectData, webhooks interface{}) error {
+	name := this.env.GetName()
+	kind := reflect.TypeOf(data).Elem().Name()
+
+	obj, err := c.Resources().GetObjectInto(resources.NewObjectName(name), data)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		data.SetName(name)
+		setWebhooks(data, webhooks)
+		_, err = c.Resources().CreateObject(data)
+		if err == nil {
+			this.Infof("%s %s created in cluster %s", kind, name, c.GetName())
+		}
+		return err
+	}
+
+	mod, err := obj.Modify(func(data resources.ObjectData) (bool, error) {
+		return setWebhooks(data, webhooks), nil
+	})
+	if mod {
+		this.Infof("%s %s updated in cluster %s", kind, name, c.GetName())
+	}
+	return err
+}
+
+func setWebhooks(data resources.ObjectData, webhooks interface{}) bool {
+	switch o := data.(type) {
+	case *adminreg.ValidatingWebhookConfiguration:
+		if reflect.DeepEqual(o.Webhooks, webhooks) {
+			return false
+		}
+		o.Webhooks = webhooks.([]adminreg.ValidatingWebhook)
+	case *adminreg.MutatingWebhookConfiguration:
+		if reflect.DeepEqual(o.Webhooks, webhooks) {
+			return false
+		}
+		o.Webhooks = webhooks.([]adminreg.MutatingWebhook)
+	}
+	return true
+}
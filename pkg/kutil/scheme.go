@@ -17,6 +17,7 @@
 package kutil
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -48,11 +49,16 @@ func IsListType(t reflect.Type) (reflect.Type, bool) {
 
 var unstructuredType = reflect.TypeOf(unstructured.Unstructured{})
 var unstructuredListType = reflect.TypeOf(unstructured.UnstructuredList{})
+var metadataType = reflect.TypeOf(metav1.PartialObjectMetadata{})
+var metadataListType = reflect.TypeOf(metav1.PartialObjectMetadataList{})
 
 func DetermineListType(s *runtime.Scheme, gv schema.GroupVersion, t reflect.Type) reflect.Type {
 	if t == unstructuredType {
 		return unstructuredListType
 	}
+	if t == metadataType {
+		return metadataListType
+	}
 	for _gvk, _t := range s.AllKnownTypes() {
 		if gv == _gvk.GroupVersion() {
 			e, ok := IsListType(_t)
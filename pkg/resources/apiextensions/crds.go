@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -81,11 +82,23 @@ func CreateCRD(cluster resources.Cluster, groupName, version, rkind, rplural, sh
 }
 
 func CreateCRDFromObject(cluster resources.Cluster, crd *v1beta1.CustomResourceDefinition) error {
+	if cluster.Resources().ResourceContext().IsDryRun() {
+		logger.Infof("DRY-RUN skipping deployment of CRD %s", crd.Name)
+		return nil
+	}
 	_, err := cluster.Resources().CreateObject(crd)
 	if err != nil && !errors.IsAlreadyExists(err) {
 		return fmt.Errorf("failed to create CRD %s: %s", crd.Name, err)
 	}
-	return WaitCRDReady(cluster, crd.Name)
+	err = WaitCRDReady(cluster, crd.Name)
+	if err != nil {
+		return err
+	}
+	// make the new resource known to the cluster
+	if err := cluster.Resources().ResourceContext().Refresh(); err != nil {
+		logger.Warnf("discovery refresh for cluster %s failed: %s", cluster.GetName(), err)
+	}
+	return nil
 }
 
 func WaitCRDReady(cluster resources.Cluster, crdName string) error {
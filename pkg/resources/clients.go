@@ -32,6 +32,7 @@ type Clients struct {
 	codecfactory   serializer.CodecFactory
 	parametercodec runtime.ParameterCodec
 	clients        map[schema.GroupVersion]restclient.Interface
+	metadata       map[schema.GroupVersion]restclient.Interface
 }
 
 func NewClients(config restclient.Config, scheme *runtime.Scheme) *Clients {
@@ -39,6 +40,7 @@ func NewClients(config restclient.Config, scheme *runtime.Scheme) *Clients {
 		config:         config,
 		scheme:         scheme,
 		clients:        map[schema.GroupVersion]restclient.Interface{},
+		metadata:       map[schema.GroupVersion]restclient.Interface{},
 		codecfactory:   serializer.NewCodecFactory(scheme),
 		parametercodec: runtime.NewParameterCodec(scheme),
 	}
@@ -61,10 +63,23 @@ func (c *Clients) GetParameterCodec() runtime.ParameterCodec {
 func (c *Clients) GetClient(gv schema.GroupVersion) (restclient.Interface, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
+	return c.getClient(c.clients, c.config, gv)
+}
+
+// GetMetadataClient provides a client for the given group version decoding
+// the metadata only representation of objects (PartialObjectMetadata).
+func (c *Clients) GetMetadataClient(gv schema.GroupVersion) (restclient.Interface, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	config := c.config
+	config.NegotiatedSerializer = serializer.WithoutConversionCodecFactory{CodecFactory: metadataCodecs}
+	return c.getClient(c.metadata, config, gv)
+}
+
+func (c *Clients) getClient(clients map[schema.GroupVersion]restclient.Interface, config restclient.Config, gv schema.GroupVersion) (restclient.Interface, error) {
 	var err error
-	client := c.clients[gv]
+	client := clients[gv]
 	if client == nil {
-		config := c.config
 		config.GroupVersion = &gv
 		if gv.Group == "" {
 			config.APIPath = "/api"
@@ -81,7 +96,7 @@ func (c *Clients) GetClient(gv schema.GroupVersion) (restclient.Interface, error
 		if err != nil {
 			return nil, err
 		}
-		c.clients[gv] = client
+		clients[gv] = client
 	}
 	return client, nil
 }
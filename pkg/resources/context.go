@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 )
 
 type ResourceContext interface {
@@ -38,12 +39,27 @@ type ResourceContext interface {
 	GetClient(gv schema.GroupVersion) (restclient.Interface, error)
 
 	Resources() Resources
+	// ResourcesFor provides a view of the resources using the given name
+	// as default field manager for server side apply requests.
+	ResourcesFor(fieldManager string) Resources
 	SharedInformerFactory() SharedInformerFactory
 
 	GetPreferred(gk schema.GroupKind) (*Info, error)
 	Get(gvk schema.GroupVersionKind) (*Info, error)
 
 	GetServerVersion() *semver.Version
+
+	Refresh() error
+	AddDiscoveryHandler(h DiscoveryHandler)
+	IsAvailable(gk schema.GroupKind) bool
+
+	AddCacheTransforms(gk schema.GroupKind, name string, funcs ...TransformFunc)
+	GetCacheTransforms(gk schema.GroupKind) []TransformFunc
+	AddCacheIndex(gk schema.GroupKind, name string, index Index) error
+	GetCacheIndexes(gk schema.GroupKind) map[string]Index
+
+	// IsDryRun reports whether write requests are executed as dry-run only.
+	IsDryRun() bool
 }
 
 type resourceContext struct {
@@ -51,11 +67,15 @@ type resourceContext struct {
 	*Clients
 	Cluster
 	*runtime.Scheme
+	cacheTransforms
+	cacheIndexes
 
 	lock                  sync.Mutex
 	ctx                   context.Context
+	dryRun                bool
 	defaultResync         time.Duration
 	resources             *_resources
+	views                 map[string]*_resources
 	sharedInformerFactory *sharedInformerFactory
 }
 
@@ -74,6 +94,7 @@ func NewResourceContext(ctx context.Context, c Cluster, scheme *runtime.Scheme,
 		ResourceInfos: res,
 		Clients:       NewClients(c.Config(), scheme),
 		ctx:           ctx,
+		dryRun:        IsDryRun(ctx),
 		defaultResync: defaultResync,
 	}, nil
 
@@ -116,6 +137,28 @@ func (c *resourceContext) SharedInformerFactory() SharedInformerFactory {
 	return c.sharedInformerFactory
 }
 
+// AddCacheIndex registers a named index for the informer caches of a
+// resource. Indexes are added to already existing informers, as long as
+// they are not yet started. Registering an index with the same
+// descriptor again is a no-op.
+func (c *resourceContext) AddCacheIndex(gk schema.GroupKind, name string, index Index) error {
+	added, err := c.addCacheIndex(gk, name, index)
+	if err != nil || !added {
+		return err
+	}
+	c.lock.Lock()
+	factory := c.sharedInformerFactory
+	c.lock.Unlock()
+	if factory != nil {
+		return factory.addIndexers(gk, cache.Indexers{name: index.indexFunc()})
+	}
+	return nil
+}
+
+func (c *resourceContext) IsDryRun() bool {
+	return c.dryRun
+}
+
 func (c *resourceContext) Resources() Resources {
 	c.SharedInformerFactory()
 
@@ -132,3 +175,27 @@ func (c *resourceContext) Resources() Resources {
 	}
 	return c.resources
 }
+
+// ResourcesFor provides a view of the resources using the given name as
+// default field manager for server side apply requests, for example the
+// name of a controller. The views share the informers and the event
+// recorder with Resources().
+func (c *resourceContext) ResourcesFor(fieldManager string) Resources {
+	base := c.Resources().(*_resources)
+	if fieldManager == "" || fieldManager == base.FieldManager() {
+		return base
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.views == nil {
+		c.views = map[string]*_resources{}
+	}
+	view := c.views[fieldManager]
+	if view == nil {
+		view = base.view(fieldManager)
+		c.views[fieldManager] = view
+	}
+	return view
+}
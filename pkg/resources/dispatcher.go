@@ -0,0 +1,107 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/client-go/tools/cache"
+)
+
+// eventDispatcher is registered only once at a shared informer and
+// dispatches the events to handlers, which can be removed again.
+// client-go does not support removing handlers from shared informers.
+type eventDispatcher struct {
+	lock     sync.RWMutex
+	handlers []*removableHandler
+}
+
+type removableHandler struct {
+	cache.ResourceEventHandler
+}
+
+var _ cache.ResourceEventHandler = &eventDispatcher{}
+
+func (this *eventDispatcher) add(h *removableHandler) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.handlers = append(this.handlers, h)
+}
+
+func (this *eventDispatcher) remove(h *removableHandler) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for i, e := range this.handlers {
+		if e == h {
+			this.handlers = append(this.handlers[:i:i], this.handlers[i+1:]...)
+			return
+		}
+	}
+}
+
+func (this *eventDispatcher) get() []*removableHandler {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.handlers
+}
+
+func (this *eventDispatcher) OnAdd(obj interface{}) {
+	for _, h := range this.get() {
+		h.OnAdd(obj)
+	}
+}
+
+func (this *eventDispatcher) OnUpdate(old, new interface{}) {
+	for _, h := range this.get() {
+		h.OnUpdate(old, new)
+	}
+}
+
+func (this *eventDispatcher) OnDelete(obj interface{}) {
+	for _, h := range this.get() {
+		h.OnDelete(obj)
+	}
+}
+
+// AddRemovableEventHandler adds an event handler, which is removed again
+// when the given context is done. Like for handlers added to an already
+// started informer, the objects already cached are passed as added.
+func (f *genericInformer) AddRemovableEventHandler(ctx context.Context, handler cache.ResourceEventHandler) {
+	h := &removableHandler{handler}
+
+	f.lock.Lock()
+	dispatcher := f.dispatcher
+	if dispatcher == nil {
+		// the informer passes the cached objects to the new dispatcher
+		dispatcher = &eventDispatcher{}
+		dispatcher.add(h)
+		f.dispatcher = dispatcher
+		f.SharedIndexInformer.AddEventHandler(dispatcher)
+		f.lock.Unlock()
+	} else {
+		f.lock.Unlock()
+		dispatcher.add(h)
+		for _, obj := range f.GetStore().List() {
+			h.OnAdd(obj)
+		}
+	}
+	go func() {
+		<-ctx.Done()
+		dispatcher.remove(h)
+	}()
+}
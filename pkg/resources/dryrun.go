@@ -0,0 +1,111 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+// ATTR_DRYRUN is the context attribute enabling the dry-run mode for the
+// resource contexts created with the context. In this mode all write
+// requests are executed as server side dry-run and the changes are logged.
+// Events are not recorded, and finalizers and CRDs are not touched.
+const ATTR_DRYRUN = "dry-run"
+
+// IsDryRun checks whether the dry-run mode is enabled for a context.
+func IsDryRun(ctx context.Context) bool {
+	dryrun, _ := ctx.Value(ATTR_DRYRUN).(bool)
+	return dryrun
+}
+
+// dryRun marks a write request as server side dry-run in dry-run mode.
+func (this *_i_resource) dryRun(req *restclient.Request) *restclient.Request {
+	if this.context.dryRun {
+		return req.Param("dryRun", metav1.DryRunAll)
+	}
+	return req
+}
+
+// dryRunDeleteOptions provides the options for a delete request. The
+// dry-run mode is passed with the options, because the API server ignores
+// the query parameters for requests with options.
+func (this *_i_resource) dryRunDeleteOptions(opts *metav1.DeleteOptions) *metav1.DeleteOptions {
+	if opts == nil {
+		opts = &metav1.DeleteOptions{}
+	}
+	if this.context.dryRun {
+		opts = opts.DeepCopy()
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	return opts
+}
+
+// dryRunCurrent reads the current state of an object before a write
+// request in dry-run mode, to log the changes of the request.
+func (this *_i_resource) dryRunCurrent(name ObjectDataName) ObjectData {
+	if !this.context.dryRun {
+		return nil
+	}
+	data := this.helper.CreateData(name)
+	if err := this.I_get(data); err != nil {
+		return nil
+	}
+	return data
+}
+
+// logDryRun logs the changes of a successful write request in dry-run
+// mode as merge patch for the old state of the object. Without old state
+// the complete result is logged.
+func (this *_i_resource) logDryRun(op string, name ObjectDataName, old, result ObjectData, err error) {
+	if !this.context.dryRun || err != nil {
+		return
+	}
+	desc := op + " " + this.GroupKind().String() + "/" + name.GetNamespace() + "/" + name.GetName()
+	if result == nil {
+		logger.Infof("DRY-RUN %s", desc)
+		return
+	}
+	result = cleanDryRunState(result)
+	var changes []byte
+	if old != nil {
+		changes, err = CreateMergePatch(cleanDryRunState(old), result)
+	} else {
+		changes, err = json.Marshal(result)
+	}
+	if err != nil {
+		logger.Warnf("DRY-RUN %s: cannot determine changes: %s", desc, err)
+		return
+	}
+	logger.Infof("DRY-RUN %s: %s", desc, changes)
+}
+
+// cleanDryRunState removes the fields maintained by the API server.
+func cleanDryRunState(data ObjectData) ObjectData {
+	data = data.DeepCopyObject().(ObjectData)
+	data.SetUID("")
+	data.SetResourceVersion("")
+	data.SetGeneration(0)
+	data.SetCreationTimestamp(metav1.Time{})
+	data.SetManagedFields(nil)
+	return data
+}
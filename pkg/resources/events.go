@@ -21,10 +21,10 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-func convert(resource Interface, funcs *ResourceEventHandlerFuncs) *cache.ResourceEventHandlerFuncs {
+func convert(resource *_resource, funcs *ResourceEventHandlerFuncs) *cache.ResourceEventHandlerFuncs {
 	return &cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			o, err := resource.Wrap(obj.(ObjectData))
+			o, err := resource.wrapCached(obj.(ObjectData))
 			if err == nil {
 				funcs.AddFunc(o)
 			}
@@ -47,15 +47,15 @@ func convert(resource Interface, funcs *ResourceEventHandlerFuncs) *cache.Resour
 					return
 				}
 			}
-			o, err := resource.Wrap(data)
+			o, err := resource.wrapCached(data)
 			if err == nil {
 				funcs.DeleteFunc(o)
 			}
 		},
 		UpdateFunc: func(old, new interface{}) {
-			o, err := resource.Wrap(old.(ObjectData))
+			o, err := resource.wrapCached(old.(ObjectData))
 			if err == nil {
-				n, err := resource.Wrap(new.(ObjectData))
+				n, err := resource.wrapCached(new.(ObjectData))
 				if err == nil {
 					funcs.UpdateFunc(o, n)
 				}
@@ -0,0 +1,173 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/fieldpath"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// IndexFunc provides the index values for an object stored in an
+// informer cache. It is used for named cache indexes, which can be
+// queried with ListCachedByIndex.
+type IndexFunc func(obj ObjectData) ([]string, error)
+
+// Index is a cache index declaration. It combines an IndexFunc with a
+// descriptor declaring what is indexed. Two declarations for the same
+// index name are considered equal if their descriptors are equal.
+type Index struct {
+	descriptor string
+	function   IndexFunc
+}
+
+// Descriptor returns the declaration of the indexed value, for example
+// "field:.Spec.ClusterIP" or "annotation:<name>".
+func (this Index) Descriptor() string {
+	return this.descriptor
+}
+
+// Function returns the IndexFunc of the index.
+func (this Index) Function() IndexFunc {
+	return this.function
+}
+
+// FieldPathIndex provides an index using the value of a field path
+// (see package fieldpath) of the object type, for example
+// ".Spec.ClusterIP" or ".Spec.Rules[].Host". Nil values and fields not
+// reachable for an object are not indexed.
+func FieldPathIndex(path string) Index {
+	n, err := fieldpath.Compile(path)
+	if err != nil {
+		panic(fmt.Sprintf("invalid field path %q for index: %s", path, err))
+	}
+	return Index{
+		descriptor: "field:" + path,
+		function: func(obj ObjectData) ([]string, error) {
+			v, err := n.Get(obj)
+			if err != nil {
+				return nil, nil
+			}
+			return indexValues(nil, v), nil
+		},
+	}
+}
+
+func indexValues(values []string, v interface{}) []string {
+	v = fieldpath.Value(v)
+	if v == nil {
+		return values
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			values = indexValues(values, rv.Index(i).Interface())
+		}
+		return values
+	case reflect.String:
+		if rv.Len() == 0 {
+			return values
+		}
+	}
+	return append(values, fmt.Sprintf("%v", v))
+}
+
+// AnnotationIndex provides an index using the value of an annotation.
+func AnnotationIndex(name string) Index {
+	return Index{
+		descriptor: "annotation:" + name,
+		function: func(obj ObjectData) ([]string, error) {
+			if v, ok := obj.GetAnnotations()[name]; ok {
+				return []string{v}, nil
+			}
+			return nil, nil
+		},
+	}
+}
+
+// CustomIndex provides an index for a custom IndexFunc. The id declares
+// the indexed value. It must be the same for all registrations of the
+// same index and different for different index functions.
+func CustomIndex(id string, f IndexFunc) Index {
+	return Index{
+		descriptor: "custom:" + id,
+		function:   f,
+	}
+}
+
+func (this Index) indexFunc() cache.IndexFunc {
+	return func(obj interface{}) ([]string, error) {
+		data, ok := obj.(ObjectData)
+		if !ok {
+			return nil, nil
+		}
+		return this.function(data)
+	}
+}
+
+type cacheIndexes struct {
+	lock    sync.RWMutex
+	indexes map[schema.GroupKind]map[string]Index
+}
+
+// addCacheIndex registers an index and reports whether it is new.
+// Registering an index with the same descriptor again for a name is
+// accepted.
+func (this *cacheIndexes) addCacheIndex(gk schema.GroupKind, name string, index Index) (bool, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.indexes == nil {
+		this.indexes = map[schema.GroupKind]map[string]Index{}
+	}
+	indexes := this.indexes[gk]
+	if indexes == nil {
+		indexes = map[string]Index{}
+		this.indexes[gk] = indexes
+	}
+	if old, ok := indexes[name]; ok {
+		if old.descriptor == index.descriptor {
+			return false, nil
+		}
+		return false, fmt.Errorf("cache index %q already defined for %s with %s (requested %s)", name, gk, old.descriptor, index.descriptor)
+	}
+	indexes[name] = index
+	return true, nil
+}
+
+func (this *cacheIndexes) GetCacheIndexes(gk schema.GroupKind) map[string]Index {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	indexes := map[string]Index{}
+	for n, f := range this.indexes[gk] {
+		indexes[n] = f
+	}
+	return indexes
+}
+
+func (this *cacheIndexes) indexers(gk schema.GroupKind) cache.Indexers {
+	indexers := cache.Indexers{}
+	for n, f := range this.GetCacheIndexes(gk) {
+		indexers[n] = f.indexFunc()
+	}
+	return indexers
+}
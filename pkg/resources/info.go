@@ -88,6 +88,10 @@ func (this *Info) InfoString() string {
 	return fmt.Sprintf("%s %s %t", this.resourcename, this.kind, this.namespaced)
 }
 
+// DiscoveryHandler is notified about the group kinds found by
+// a discovery refresh, which were not available before.
+type DiscoveryHandler func(added []schema.GroupKind)
+
 type ResourceInfos struct {
 	lock              sync.RWMutex
 	groupVersionKinds map[schema.GroupVersion]map[string]*Info
@@ -95,6 +99,7 @@ type ResourceInfos struct {
 	cluster           Cluster
 	mapper            meta.RESTMapper
 	version           *semver.Version
+	handlers          []DiscoveryHandler
 }
 
 func NewResourceInfos(c Cluster) (*ResourceInfos, error) {
@@ -151,32 +156,39 @@ func (this *ResourceInfos) update() error {
 	}
 
 	//list, err := discovery.ServerResources(dc)
+	// on partial discovery failures, entries of the failed groups are kept
+	partial := false
 	_, list, err := dc.ServerGroupsAndResources()
 	if err != nil {
+		partial = true
 		logger.Warnf("failed to get all server resources for cluster %s: %s", this.cluster.GetName(), err)
 		if len(list) == 0 {
 			return err
 		}
 		logger.Infof("found %d resources", len(list))
 	}
-	this.lock.Lock()
-	defer this.lock.Unlock()
+	groupVersionKinds := map[schema.GroupVersion]map[string]*Info{}
 	for _, rl := range list {
 		gv, _ := schema.ParseGroupVersion(rl.GroupVersion)
 
-		m := this.groupVersionKinds[gv]
+		m := groupVersionKinds[gv]
 		if m == nil {
 			m = map[string]*Info{}
-			this.groupVersionKinds[gv] = m
+			groupVersionKinds[gv] = m
 		}
+		// subresources may have a kind of their own (for example Scale),
+		// therefore they are assigned by the resource name
+		byName := map[string]*Info{}
 		for _, r := range rl.APIResources {
 			if strings.Index(r.Name, "/") < 0 {
-				m[r.Kind] = &Info{groupVersion: &gv, resourcename: r.Name, kind: r.Kind, namespaced: r.Namespaced, subresources: utils.StringSet{}}
+				info := &Info{groupVersion: &gv, resourcename: r.Name, kind: r.Kind, namespaced: r.Namespaced, subresources: utils.StringSet{}}
+				m[r.Kind] = info
+				byName[r.Name] = info
 			}
 		}
 		for _, r := range rl.APIResources {
 			if i := strings.Index(r.Name, "/"); i > 0 {
-				info := m[r.Kind]
+				info := byName[r.Name[:i]]
 				if info != nil {
 					info.subresources.Add(r.Name[i+1:])
 				}
@@ -186,20 +198,76 @@ func (this *ResourceInfos) update() error {
 
 	list, err = dc.ServerPreferredResources()
 	if err != nil {
+		partial = true
 		logger.Warnf("*** failed to get all preferred server resources for cluster %s: %s", this.cluster.GetName(), err)
 		if len(list) == 0 {
 			return err
 		}
 		logger.Infof("found %d resources", len(list))
 	}
+	// the list contains all versions of a group, the preferred one first
+	preferredVersions := map[string]string{}
 	for _, rl := range list {
 		gv, _ := schema.ParseGroupVersion(rl.GroupVersion)
-		this.preferredVersions[gv.Group] = gv.Version
+		if _, ok := preferredVersions[gv.Group]; !ok {
+			preferredVersions[gv.Group] = gv.Version
+		}
+	}
+
+	this.lock.Lock()
+	if partial {
+		for gv, m := range this.groupVersionKinds {
+			if groupVersionKinds[gv] == nil {
+				groupVersionKinds[gv] = m
+			}
+		}
+		for g, v := range this.preferredVersions {
+			if _, ok := preferredVersions[g]; !ok {
+				preferredVersions[g] = v
+			}
+		}
+	}
+	added := []schema.GroupKind{}
+	for g, v := range preferredVersions {
+		for k := range groupVersionKinds[schema.GroupVersion{Group: g, Version: v}] {
+			gk := schema.GroupKind{Group: g, Kind: k}
+			if this.lookupPreferred(gk) == nil {
+				added = append(added, gk)
+			}
+		}
 	}
+	this.groupVersionKinds = groupVersionKinds
+	this.preferredVersions = preferredVersions
+	handlers := append([]DiscoveryHandler{}, this.handlers...)
+	this.lock.Unlock()
 
+	if len(added) > 0 {
+		for _, h := range handlers {
+			h(added)
+		}
+	}
 	return nil
 }
 
+// Refresh updates the resource infos by a new discovery.
+func (this *ResourceInfos) Refresh() error {
+	return this.update()
+}
+
+// AddDiscoveryHandler adds a handler notified about group kinds,
+// which become available by a later discovery refresh.
+func (this *ResourceInfos) AddDiscoveryHandler(h DiscoveryHandler) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.handlers = append(this.handlers, h)
+}
+
+// IsAvailable checks whether a group kind is known by the last
+// discovery without triggering a new one.
+func (this *ResourceInfos) IsAvailable(gk schema.GroupKind) bool {
+	return this.getPreferred(gk) != nil
+}
+
 func (this *ResourceInfos) GetGroups() []schema.GroupVersion {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
@@ -242,6 +310,10 @@ func (this *ResourceInfos) GetPreferred(gk schema.GroupKind) (*Info, error) {
 func (this *ResourceInfos) getPreferred(gk schema.GroupKind) *Info {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
+	return this.lookupPreferred(gk)
+}
+
+func (this *ResourceInfos) lookupPreferred(gk schema.GroupKind) *Info {
 	v, ok := this.preferredVersions[gk.Group]
 	if !ok {
 		return nil
@@ -118,7 +118,13 @@ func (f *genericInformerFactory) informerFor(informerType reflect.Type, gvk sche
 		return nil, fmt.Errorf("no list type found for %s", informerType)
 	}
 
-	client, err := f.getClient(gvk.GroupVersion())
+	var client restclient.Interface
+	var err error
+	if informerType == metadataType {
+		client, err = f.context.GetMetadataClient(gvk.GroupVersion())
+	} else {
+		client, err = f.getClient(gvk.GroupVersion())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -133,6 +139,30 @@ func (f *genericInformerFactory) informerFor(informerType reflect.Type, gvk sche
 	return informer, nil
 }
 
+func (f *genericInformerFactory) addIndexers(gk schema.GroupKind, indexers cache.Indexers) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for gvk, informer := range f.informers {
+		if gvk.GroupKind() != gk {
+			continue
+		}
+		missing := cache.Indexers{}
+		existing := informer.GetIndexer().GetIndexers()
+		for n, i := range indexers {
+			if _, ok := existing[n]; !ok {
+				missing[n] = i
+			}
+		}
+		if len(missing) > 0 {
+			if err := informer.AddIndexers(missing); err != nil {
+				return fmt.Errorf("cannot add cache index for %s: %s", gvk, err)
+			}
+		}
+	}
+	return nil
+}
+
 func (f *genericInformerFactory) queryInformerFor(informerType reflect.Type, gvk schema.GroupVersionKind) GenericInformer {
 	f.lock.Lock()
 	defer f.lock.Unlock()
@@ -150,7 +180,9 @@ func (f *genericInformerFactory) getClient(gv schema.GroupVersion) (restclient.I
 
 func (f *genericInformerFactory) newInformer(client restclient.Interface, res *Info, elemType reflect.Type, listType reflect.Type) GenericInformer {
 	logger.Infof("new generic informer for %s (%s) %s (%d seconds)", elemType, res.GroupVersionKind(), listType, f.defaultResync/time.Second)
-	indexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	indexers := f.context.indexers(res.GroupKind())
+	indexers[cache.NamespaceIndex] = cache.MetaNamespaceIndexFunc
+	metadata := elemType == metadataType
 	informer := cache.NewSharedIndexInformer(
 		&cache.ListWatch{
 			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
@@ -164,8 +196,18 @@ func (f *genericInformerFactory) newInformer(client restclient.Interface, res *I
 				if res.Namespaced() {
 					r = r.Namespace(f.namespace)
 				}
-
-				return result, r.Do().Into(result)
+				if metadata {
+					r = r.SetHeader("Accept", ACCEPT_METADATA_LIST)
+				}
+				err := r.Do().Into(result)
+				if err != nil {
+					return result, err
+				}
+				if metadata {
+					setMetadataKind(result, res.GroupVersionKind())
+				}
+				f.context.transform(res.GroupKind(), result)
+				return result, nil
 			},
 			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
 				options.Watch = true
@@ -178,13 +220,22 @@ func (f *genericInformerFactory) newInformer(client restclient.Interface, res *I
 				if res.Namespaced() {
 					r = r.Namespace(f.namespace)
 				}
-
-				return r.Watch()
+				if metadata {
+					r = r.SetHeader("Accept", ACCEPT_METADATA)
+				}
+				w, err := r.Watch()
+				if err != nil {
+					return nil, err
+				}
+				if metadata {
+					w = metadataWatch(w, res.GroupVersionKind())
+				}
+				return f.context.transformWatch(res.GroupKind(), w), nil
 			},
 		},
 		reflect.New(elemType).Interface().(runtime.Object),
 		f.defaultResync,
 		indexers,
 	)
-	return &genericInformer{informer, res}
+	return &genericInformer{SharedIndexInformer: informer, resource: res}
 }
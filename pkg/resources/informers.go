@@ -36,11 +36,17 @@ type GenericInformer interface {
 	cache.SharedIndexInformer
 	Informer() cache.SharedIndexInformer
 	Lister() Lister
+	// AddRemovableEventHandler adds an event handler, which is removed
+	// again when the given context is done.
+	AddRemovableEventHandler(ctx context.Context, handler cache.ResourceEventHandler)
 }
 
 type genericInformer struct {
 	cache.SharedIndexInformer
 	resource *Info
+
+	lock       sync.Mutex
+	dispatcher *eventDispatcher
 }
 
 func (f *genericInformer) Informer() cache.SharedIndexInformer {
@@ -56,6 +62,8 @@ func (f *genericInformer) Lister() Lister {
 type SharedInformerFactory interface {
 	Structured() GenericFilteredInformerFactory
 	Unstructured() GenericFilteredInformerFactory
+	// Metadata provides informers caching only the metadata of objects
+	Metadata() GenericFilteredInformerFactory
 
 	InformerForObject(obj runtime.Object) (GenericInformer, error)
 	FilteredInformerForObject(obj runtime.Object, namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error)
@@ -66,6 +74,9 @@ type SharedInformerFactory interface {
 	UnstructuredInformerFor(gvk schema.GroupVersionKind) (GenericInformer, error)
 	FilteredUnstructuredInformerFor(gvk schema.GroupVersionKind, namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error)
 
+	MetadataInformerFor(gvk schema.GroupVersionKind) (GenericInformer, error)
+	FilteredMetadataInformerFor(gvk schema.GroupVersionKind, namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error)
+
 	Start(stopCh <-chan struct{})
 	WaitForCacheSync(stopCh <-chan struct{})
 }
@@ -89,6 +100,7 @@ type sharedInformerFactory struct {
 	context      *resourceContext
 	structured   *sharedFilteredInformerFactory
 	unstructured *unstructuredSharedFilteredInformerFactory
+	metadata     *metadataSharedFilteredInformerFactory
 }
 
 func newSharedInformerFactory(rctx *resourceContext, defaultResync time.Duration) *sharedInformerFactory {
@@ -96,6 +108,7 @@ func newSharedInformerFactory(rctx *resourceContext, defaultResync time.Duration
 		context:      rctx,
 		structured:   newSharedFilteredInformerFactory(rctx, defaultResync),
 		unstructured: &unstructuredSharedFilteredInformerFactory{newSharedFilteredInformerFactory(rctx, defaultResync)},
+		metadata:     &metadataSharedFilteredInformerFactory{newSharedFilteredInformerFactory(rctx, defaultResync)},
 	}
 }
 
@@ -107,15 +120,30 @@ func (f *sharedInformerFactory) Unstructured() GenericFilteredInformerFactory {
 	return f.unstructured
 }
 
+func (f *sharedInformerFactory) Metadata() GenericFilteredInformerFactory {
+	return f.metadata
+}
+
 // Start initializes all requested informers.
 func (f *sharedInformerFactory) Start(stopCh <-chan struct{}) {
 	f.structured.Start(stopCh)
 	f.unstructured.Start(stopCh)
+	f.metadata.Start(stopCh)
 }
 
 func (f *sharedInformerFactory) WaitForCacheSync(stopCh <-chan struct{}) {
 	f.structured.WaitForCacheSync(stopCh)
 	f.unstructured.WaitForCacheSync(stopCh)
+	f.metadata.WaitForCacheSync(stopCh)
+}
+
+func (f *sharedInformerFactory) addIndexers(gk schema.GroupKind, indexers cache.Indexers) error {
+	for _, factory := range []*sharedFilteredInformerFactory{f.structured, f.unstructured.sharedFilteredInformerFactory, f.metadata.sharedFilteredInformerFactory} {
+		if err := factory.addIndexers(gk, indexers); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (f *sharedInformerFactory) UnstructuredInformerFor(gvk schema.GroupVersionKind) (GenericInformer, error) {
@@ -126,6 +154,14 @@ func (f *sharedInformerFactory) FilteredUnstructuredInformerFor(gvk schema.Group
 	return f.unstructured.informerFor(unstructuredType, gvk, namespace, optionsFunc)
 }
 
+func (f *sharedInformerFactory) MetadataInformerFor(gvk schema.GroupVersionKind) (GenericInformer, error) {
+	return f.metadata.informerFor(metadataType, gvk, "", nil)
+}
+
+func (f *sharedInformerFactory) FilteredMetadataInformerFor(gvk schema.GroupVersionKind, namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error) {
+	return f.metadata.informerFor(metadataType, gvk, namespace, optionsFunc)
+}
+
 func (f *sharedInformerFactory) InformerFor(gvk schema.GroupVersionKind) (GenericInformer, error) {
 	return f.FilteredInformerFor(gvk, "", nil)
 }
@@ -198,6 +234,17 @@ func (f *sharedFilteredInformerFactory) WaitForCacheSync(stopCh <-chan struct{})
 	}
 }
 
+func (f *sharedFilteredInformerFactory) addIndexers(gk schema.GroupKind, indexers cache.Indexers) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for _, i := range f.filters {
+		if err := i.addIndexers(gk, indexers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (f *sharedFilteredInformerFactory) getFactory(namespace string, optionsFunc TweakListOptionsFunc) *genericInformerFactory {
 	key := namespace
 	if optionsFunc != nil {
@@ -277,17 +324,19 @@ type watchWrapper struct {
 	orig       watch.Interface
 	origChan   <-chan watch.Event
 	resultChan chan watch.Event
+	stop       chan struct{}
+	once       sync.Once
 }
 
+// NewWatchWrapper provides a watch stopped when the given context is done.
 func NewWatchWrapper(ctx context.Context, orig watch.Interface) watch.Interface {
-	logger.Infof("*************** new wrapper ********************")
-	w := &watchWrapper{ctx, orig, orig.ResultChan(), make(chan watch.Event)}
+	w := &watchWrapper{ctx: ctx, orig: orig, origChan: orig.ResultChan(), resultChan: make(chan watch.Event), stop: make(chan struct{})}
 	go w.Run()
 	return w
 }
 
 func (w *watchWrapper) Stop() {
-	w.orig.Stop()
+	w.once.Do(func() { close(w.stop) })
 }
 
 func (w *watchWrapper) ResultChan() <-chan watch.Event {
@@ -299,17 +348,23 @@ loop:
 		select {
 		case <-w.ctx.Done():
 			break loop
+		case <-w.stop:
+			break loop
 		case e, ok := <-w.origChan:
 			if !ok {
-				logger.Infof("watch aborted")
+				logger.Debugf("watch aborted")
+				break loop
+			}
+			select {
+			case w.resultChan <- e:
+			case <-w.ctx.Done():
+				break loop
+			case <-w.stop:
 				break loop
-			} else {
-				logger.Infof("WATCH: %#v\n", e)
-				w.resultChan <- e
 			}
 		}
 	}
-	logger.Infof("stop wrapper ***************")
+	w.orig.Stop()
 	close(w.resultChan)
 }
 
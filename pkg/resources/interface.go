@@ -17,10 +17,14 @@
 package resources
 
 import (
+	"context"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -92,6 +96,10 @@ type ResourceEventHandlerFuncs struct {
 
 type Modifier func(ObjectData) (bool, error)
 
+// ObjectConsumer is called for the objects of a paginated list. An error
+// aborts the list.
+type ObjectConsumer func(Object) error
+
 type Object interface {
 	metav1.Object
 	GroupKindProvider
@@ -111,11 +119,28 @@ type Object interface {
 	GetResource() Interface
 
 	IsA(spec interface{}) bool
+	// IsMetadataOnly indicates an object provided by a metadata
+	// watch, which just contains the metadata of the object.
+	IsMetadataOnly() bool
+	// IsPartial indicates an object served by an informer cache with
+	// cache transformations, which must not be written back completely.
+	IsPartial() bool
+	// GetFullObject provides the complete object. For metadata only
+	// objects it is read from the cluster.
+	GetFullObject() (Object, error)
 	Create() error
 	CreateOrUpdate() error
 	Delete() error
 	Update() error
 	UpdateStatus() error
+	// Apply applies the object with a server side apply request.
+	// If no field manager is given, the default of the Resources is used.
+	Apply(fieldManager string, force bool) error
+	ApplyStatus(fieldManager string, force bool) error
+	// Patch updates the object with a JSON merge patch for the differences
+	// to the given modified object data, optionally with optimistic locking.
+	Patch(modified ObjectData, optimisticLock bool) (bool, error)
+	PatchStatus(modified ObjectData, optimisticLock bool) (bool, error)
 	Modify(modifier Modifier) (bool, error)
 	ModifyStatus(modifier Modifier) (bool, error)
 	CreateOrModify(modifier Modifier) (bool, error)
@@ -174,6 +199,14 @@ type Interface interface {
 	AddSelectedEventHandler(eventHandlers ResourceEventHandlerFuncs, namespace string, optionsFunc TweakListOptionsFunc) error
 	AddEventHandler(eventHandlers ResourceEventHandlerFuncs) error
 	AddRawEventHandler(handlers cache.ResourceEventHandlerFuncs) error
+	AddMetadataEventHandler(eventHandlers ResourceEventHandlerFuncs) error
+	AddCacheTransforms(name string, funcs ...TransformFunc)
+	AddCacheIndex(name string, index Index) error
+	AddSelectedMetadataEventHandler(eventHandlers ResourceEventHandlerFuncs, namespace string, optionsFunc TweakListOptionsFunc) error
+	// AddRemovableEventHandler adds an event handler to a shared informer,
+	// for metadata only or complete objects, which is removed again when
+	// the given context is done.
+	AddRemovableEventHandler(ctx context.Context, eventHandlers ResourceEventHandlerFuncs, namespace string, optionsFunc TweakListOptionsFunc, metadata bool) error
 
 	Wrap(ObjectData) (Object, error)
 	New(ObjectName) Object
@@ -183,16 +216,44 @@ type Interface interface {
 	GetCached(interface{}) (Object, error)
 	Get_(obj interface{}) (Object, error)
 	ListCached(selector labels.Selector) ([]Object, error)
+	// GetCachedMetadata and ListCachedMetadata use the cache of a metadata
+	// informer. The objects just contain the metadata (see IsMetadataOnly).
+	GetCachedMetadata(interface{}) (Object, error)
+	ListCachedMetadata(selector labels.Selector) ([]Object, error)
+	ListCachedByIndex(indexName, value string) ([]Object, error)
 	List(opts metav1.ListOptions) (ret []Object, err error)
+	// ListPaged lists the objects with requests for pages of the given size
+	// and passes them to the consumer.
+	ListPaged(opts metav1.ListOptions, pageSize int64, consumer ObjectConsumer) error
+	// Watch watches the objects of a namespace, all namespaces for an
+	// empty namespace.
+	Watch(namespace string, opts metav1.ListOptions) (ObjectWatch, error)
+	// WaitFor waits until the condition is met for the given object.
+	WaitFor(obj ObjectDataName, condition ObjectCondition, timeout time.Duration) (Object, error)
 	Create(ObjectData) (Object, error)
 	CreateOrUpdate(obj ObjectData) (Object, error)
 	Update(ObjectData) (Object, error)
+	Apply(obj ObjectData, fieldManager string, force bool) (Object, error)
+	ApplyStatus(obj ObjectData, fieldManager string, force bool) (Object, error)
+	Patch(name ObjectDataName, pt types.PatchType, data []byte) (Object, error)
+	PatchStatus(name ObjectDataName, pt types.PatchType, data []byte) (Object, error)
 	Modify(obj ObjectData, modifier Modifier) (ObjectData, bool, error)
 	ModifyByName(obj ObjectDataName, modifier Modifier) (Object, bool, error)
 	ModifyStatus(obj ObjectData, modifier Modifier) (ObjectData, bool, error)
 	ModifyStatusByName(obj ObjectDataName, modifier Modifier) (Object, bool, error)
 	Delete(ObjectData) error
 	DeleteByName(ObjectDataName) error
+	DeleteCollection(namespace string, selector labels.Selector, options *metav1.DeleteOptions) error
+	// ModifyAll applies a modifier to all objects matching the label
+	// selector with bounded concurrency.
+	ModifyAll(selector labels.Selector, modifier Modifier) (int, error)
+
+	// Subresources other than status are accessed with dedicated objects
+	// (for example autoscaling/v1 Scale). The requests fail for
+	// subresources not offered by the resource.
+	GetSubResource(name ObjectDataName, subresource string, result runtime.Object) error
+	UpdateSubResource(name ObjectDataName, subresource string, data, result runtime.Object) error
+	CreateSubResource(name ObjectDataName, subresource string, data, result runtime.Object) error
 
 	NormalEventf(name ObjectDataName, reason, msgfmt string, args ...interface{})
 	WarningEventf(name ObjectDataName, reason, msgfmt string, args ...interface{})
@@ -204,15 +265,32 @@ type Interface interface {
 
 type Namespaced interface {
 	ListCached(selector labels.Selector) ([]Object, error)
+	ListCachedByIndex(indexName, value string) ([]Object, error)
 	List(opts metav1.ListOptions) (ret []Object, err error)
+	ListPaged(opts metav1.ListOptions, pageSize int64, consumer ObjectConsumer) error
+	Watch(opts metav1.ListOptions) (ObjectWatch, error)
 	GetCached(name string) (Object, error)
+	GetCachedMetadata(name string) (Object, error)
+	ListCachedMetadata(selector labels.Selector) ([]Object, error)
 	Get(name string) (Object, error)
+
+	// The write operations reject objects of other namespaces. Objects
+	// without namespace are assigned to the namespace of the view.
+	Create(ObjectData) (Object, error)
+	Update(ObjectData) (Object, error)
+	Modify(obj ObjectData, modifier Modifier) (ObjectData, bool, error)
+	ModifyStatus(obj ObjectData, modifier Modifier) (ObjectData, bool, error)
+	Delete(ObjectData) error
+	DeleteByName(name string) error
+	DeleteCollection(selector labels.Selector) error
 }
 
 type Resources interface {
 	ResourcesSource
 	record.EventRecorder
 
+	ResourceContext() ResourceContext
+
 	Get(interface{}) (Interface, error)
 	GetByExample(obj runtime.Object) (Interface, error)
 	GetByGK(gk schema.GroupKind) (Interface, error)
@@ -230,8 +308,15 @@ type Resources interface {
 
 	CreateObject(ObjectData) (Object, error)
 	CreateOrUpdateObject(obj ObjectData) (Object, error)
+	ApplyObject(obj ObjectData, fieldManager string, force bool) (Object, error)
 
 	DeleteObject(obj ObjectData) error
+
+	// FieldManager is the default field manager for server side apply
+	// requests without explicit field manager. For the resources of a
+	// controller it is the name of the controller, otherwise the event
+	// source of the cluster (the name of the controller manager).
+	FieldManager() string
 }
 
 // TweakListOptionsFunc defines the signature of a helper function
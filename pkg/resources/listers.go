@@ -26,6 +26,7 @@ import (
 
 type Lister interface {
 	List(selector labels.Selector, consumer func(o interface{})) error
+	ListByIndex(indexName, value string, consumer func(o interface{})) error
 	Namespace(namespace string) NamespacedLister
 	Get(name string) (ObjectData, error)
 }
@@ -50,6 +51,17 @@ func (s *lister) List(selector labels.Selector, consumer func(o interface{})) er
 	return cache.ListAll(s.indexer, selector, consumer)
 }
 
+func (s *lister) ListByIndex(indexName, value string, consumer func(o interface{})) error {
+	list, err := s.indexer.ByIndex(indexName, value)
+	if err != nil {
+		return err
+	}
+	for _, o := range list {
+		consumer(o)
+	}
+	return nil
+}
+
 func (s *lister) Get(name string) (ObjectData, error) {
 
 	if s.resource.Namespaced() {
@@ -71,6 +83,7 @@ func (s *lister) Namespace(namespace string) NamespacedLister {
 
 type NamespacedLister interface {
 	List(selector labels.Selector, consumer func(o interface{})) error
+	ListByIndex(indexName, value string, consumer func(o interface{})) error
 	Get(name string) (ObjectData, error)
 }
 
@@ -87,6 +100,22 @@ func (s *namespacedLister) List(selector labels.Selector, consumer func(o interf
 	return cache.ListAllByNamespace(s.indexer, s.namespace, selector, consumer)
 }
 
+func (s *namespacedLister) ListByIndex(indexName, value string, consumer func(o interface{})) error {
+	if !s.info.Namespaced() {
+		return errors.NewBadRequest(fmt.Sprintf("info %s is not namespaced", s.info.Name()))
+	}
+	list, err := s.indexer.ByIndex(indexName, value)
+	if err != nil {
+		return err
+	}
+	for _, o := range list {
+		if o.(ObjectData).GetNamespace() == s.namespace {
+			consumer(o)
+		}
+	}
+	return nil
+}
+
 func (s *namespacedLister) Get(name string) (ObjectData, error) {
 	if !s.info.Namespaced() {
 		return nil, errors.NewBadRequest(fmt.Sprintf("info %s is not namespaced", s.info.Name()))
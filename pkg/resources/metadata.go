@@ -0,0 +1,90 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ACCEPT_METADATA and ACCEPT_METADATA_LIST are the content types used to
+// request the metadata only representation of objects and object lists.
+const ACCEPT_METADATA = "application/json;as=PartialObjectMetadata;g=meta.k8s.io;v=v1,application/json"
+const ACCEPT_METADATA_LIST = "application/json;as=PartialObjectMetadataList;g=meta.k8s.io;v=v1,application/json"
+
+var metadataType = reflect.TypeOf(metav1.PartialObjectMetadata{})
+
+var metadataScheme = runtime.NewScheme()
+var metadataCodecs = serializer.NewCodecFactory(metadataScheme)
+
+func init() {
+	utilruntime.Must(metav1.AddMetaToScheme(metadataScheme))
+	metadataScheme.AddUnversionedTypes(schema.GroupVersion{Version: "v1"}, &metav1.Status{})
+}
+
+// IsMetadataOnly checks whether object data just contains the metadata
+// of an object as provided by a metadata informer.
+func IsMetadataOnly(data ObjectData) bool {
+	_, ok := data.(*metav1.PartialObjectMetadata)
+	return ok
+}
+
+// setMetadataKind sets the kind of the resource for metadata objects
+// instead of PartialObjectMetadata, to keep the objects usable for
+// object references and events.
+func setMetadataKind(obj runtime.Object, gvk schema.GroupVersionKind) {
+	switch o := obj.(type) {
+	case *metav1.PartialObjectMetadata:
+		o.SetGroupVersionKind(gvk)
+	case *metav1.PartialObjectMetadataList:
+		for i := range o.Items {
+			o.Items[i].SetGroupVersionKind(gvk)
+		}
+	}
+}
+
+func metadataWatch(w watch.Interface, gvk schema.GroupVersionKind) watch.Interface {
+	return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+		setMetadataKind(in.Object, gvk)
+		return in, true
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// MetadataInformers
+
+type metadataSharedFilteredInformerFactory struct {
+	*sharedFilteredInformerFactory
+}
+
+func (f *metadataSharedFilteredInformerFactory) InformerFor(gvk schema.GroupVersionKind) (GenericInformer, error) {
+	return f.informerFor(metadataType, gvk, "", nil)
+}
+
+func (f *metadataSharedFilteredInformerFactory) FilteredInformerFor(gvk schema.GroupVersionKind, namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error) {
+	return f.informerFor(metadataType, gvk, namespace, optionsFunc)
+}
+
+func (f *metadataSharedFilteredInformerFactory) LookupInformerFor(gvk schema.GroupVersionKind, namespace string) (GenericInformer, error) {
+	return f.lookupInformerFor(metadataType, gvk, namespace)
+}
@@ -24,11 +24,25 @@ import (
 
 type AbstractObject struct {
 	ObjectData
-	self I_Object
+	self    I_Object
+	partial bool
 }
 
 func NewAbstractObject(self I_Object, data ObjectData) AbstractObject {
-	return AbstractObject{data, self}
+	return AbstractObject{ObjectData: data, self: self}
+}
+
+// IsPartial indicates an object served by an informer cache of a resource
+// with cache transformations, which may lack the removed fields.
+func (this *AbstractObject) IsPartial() bool {
+	return this.partial
+}
+
+// setData replaces the object data by the complete data provided
+// by the cluster.
+func (this *AbstractObject) setData(data ObjectData) {
+	this.ObjectData = data
+	this.partial = false
 }
 
 func (this *AbstractObject) Data() ObjectData {
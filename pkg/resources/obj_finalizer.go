@@ -37,6 +37,12 @@ func (this *_object) HasFinalizer(key string) bool {
 }
 
 func (this *_object) SetFinalizer(key string) error {
+	if this.resource.ResourceContext().IsDryRun() {
+		if !this.HasFinalizer(key) {
+			logger.Infof("DRY-RUN setting finalizer %q for %q", key, this.Description())
+		}
+		return nil
+	}
 	f := func(obj ObjectData) (bool, error) {
 		if !hasFinalizer(key, obj) {
 			logger.Infof("setting finalizer %q for %q (%s)", key, this.Description(), this.GetResourceVersion())
@@ -50,6 +56,12 @@ func (this *_object) SetFinalizer(key string) error {
 }
 
 func (this *_object) RemoveFinalizer(key string) error {
+	if this.resource.ResourceContext().IsDryRun() {
+		if this.HasFinalizer(key) {
+			logger.Infof("DRY-RUN removing finalizer %q for %q", key, this.Description())
+		}
+		return nil
+	}
 	f := func(obj ObjectData) (bool, error) {
 		list := obj.GetFinalizers()
 		for i, name := range list {
@@ -47,7 +47,16 @@ func (this *_i_object) I_resource() Internal {
 func (this *_i_object) I_modify(status_only, create bool, modifier Modifier) (bool, error) {
 	var lasterr error
 
-	data := this.Data().DeepCopyObject().(ObjectData)
+	var data ObjectData
+	if this.IsMetadataOnly() || this.IsPartial() {
+		full, err := this.resource.Get_(this.ObjectName())
+		if err != nil {
+			return false, err
+		}
+		data = full.Data()
+	} else {
+		data = this.Data().DeepCopyObject().(ObjectData)
+	}
 
 	cnt := 10
 
@@ -63,7 +72,7 @@ func (this *_i_object) I_modify(status_only, create bool, modifier Modifier) (bo
 			}
 			created, err := this.resource.I_create(data)
 			if err == nil {
-				this.ObjectData = created
+				this.setData(created)
 				return true, nil
 			}
 			if !errors.IsAlreadyExists(err) {
@@ -89,7 +98,7 @@ func (this *_i_object) I_modify(status_only, create bool, modifier Modifier) (bo
 				modified, lasterr = this.resource.I_update(data)
 			}
 			if lasterr == nil {
-				this.ObjectData = modified
+				this.setData(modified)
 				return mod, nil
 			}
 			if !errors.IsConflict(lasterr) {
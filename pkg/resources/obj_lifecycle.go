@@ -17,25 +17,55 @@
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func (this *AbstractObject) Create() error {
+	if err := this.checkComplete(); err != nil {
+		return err
+	}
 	o, err := this.self.GetResource().Create(this.ObjectData)
 	if err == nil {
-		this.ObjectData = o.Data()
+		this.setData(o.Data())
 	}
 	return err
 }
 
 func (this *AbstractObject) CreateOrUpdate() error {
+	if err := this.checkWritable(); err != nil {
+		return err
+	}
 	o, err := this.self.GetResource().CreateOrUpdate(this.ObjectData)
 	if err == nil {
-		this.ObjectData = o.Data()
+		this.setData(o.Data())
 	}
 	return err
 }
 
+// checkComplete rejects writing metadata only objects, which would
+// reset all other fields of the object.
+func (this *AbstractObject) checkComplete() error {
+	if IsMetadataOnly(this.ObjectData) {
+		return fmt.Errorf("%s contains metadata only (use Modify or GetFullObject)", this.Description())
+	}
+	return nil
+}
+
+// checkWritable additionally rejects writing back partial objects served
+// by a transformed cache, which would remove the stripped fields.
+func (this *AbstractObject) checkWritable() error {
+	if err := this.checkComplete(); err != nil {
+		return err
+	}
+	if this.partial {
+		return fmt.Errorf("%s is partial because of cache transformations (use Modify or Patch)", this.Description())
+	}
+	return nil
+}
+
 func (this *AbstractObject) IsDeleting() bool {
 	return this.GetDeletionTimestamp() != nil
 }
@@ -64,25 +94,101 @@ func (this *AbstractObject) modify(create bool, modifier Modifier) (bool, error)
 // Methods using internal Resource Interface
 
 func (this *AbstractObject) Update() error {
+	if err := this.checkWritable(); err != nil {
+		return err
+	}
 	result, err := this.self.I_resource().I_update(this.ObjectData)
 	if err == nil {
-		this.ObjectData = result
+		this.setData(result)
 	}
 	return err
 }
 
 func (this *AbstractObject) UpdateStatus() error {
+	if err := this.checkWritable(); err != nil {
+		return err
+	}
 	rsc := this.self.I_resource()
 	if !rsc.Info().HasStatusSubResource() {
 		return fmt.Errorf("resource %q has no status sub resource", rsc.GroupVersionKind())
 	}
 	result, err := rsc.I_updateStatus(this.ObjectData)
 	if err == nil {
-		this.ObjectData = result
+		this.setData(result)
+	}
+	return err
+}
+
+// Apply applies the object with a server side apply request. Metadata
+// only objects are applied, also, because they just contain the fields
+// to apply.
+func (this *AbstractObject) Apply(fieldManager string, force bool) error {
+	result, err := this.self.I_resource().I_apply(this.ObjectData, false, fieldManager, force)
+	if err == nil {
+		this.setData(result)
+	}
+	return err
+}
+
+func (this *AbstractObject) ApplyStatus(fieldManager string, force bool) error {
+	rsc := this.self.I_resource()
+	if !rsc.Info().HasStatusSubResource() {
+		return fmt.Errorf("resource %q has no status sub resource", rsc.GroupVersionKind())
+	}
+	result, err := rsc.I_apply(this.ObjectData, true, fieldManager, force)
+	if err == nil {
+		this.setData(result)
 	}
 	return err
 }
 
+// Patch updates the object with a JSON merge patch for the differences
+// to the given modified object data. With optimistic locking the patch
+// includes the resource version of the object, so that it fails with a
+// conflict if the object has been changed meanwhile. As only the differences
+// are sent, partial objects can be patched, also.
+func (this *AbstractObject) Patch(modified ObjectData, optimisticLock bool) (bool, error) {
+	return this.patch(false, modified, optimisticLock)
+}
+
+// PatchStatus updates the status of the object with a JSON merge patch
+// for the differences to the given modified object data.
+func (this *AbstractObject) PatchStatus(modified ObjectData, optimisticLock bool) (bool, error) {
+	rsc := this.self.I_resource()
+	if !rsc.Info().HasStatusSubResource() {
+		return false, fmt.Errorf("resource %q has no status sub resource", rsc.GroupVersionKind())
+	}
+	return this.patch(true, modified, optimisticLock)
+}
+
+func (this *AbstractObject) patch(status bool, modified ObjectData, optimisticLock bool) (bool, error) {
+	patch, err := mergePatch(this.ObjectData, modified)
+	if err != nil {
+		return false, err
+	}
+	if len(patch) == 0 {
+		return false, nil
+	}
+	if optimisticLock {
+		metadata, ok := patch["metadata"].(map[string]interface{})
+		if !ok {
+			metadata = map[string]interface{}{}
+			patch["metadata"] = metadata
+		}
+		metadata["resourceVersion"] = this.GetResourceVersion()
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return false, err
+	}
+	result, err := this.self.I_resource().I_patch(this, status, types.MergePatchType, data)
+	if err != nil {
+		return false, err
+	}
+	this.setData(result)
+	return true, nil
+}
+
 func (this *AbstractObject) Delete() error {
 	return this.self.I_resource().I_delete(this)
 }
@@ -91,6 +197,7 @@ func (this *AbstractObject) UpdateFromCache() error {
 	obj, err := this.self.GetResource().GetCached(this.ObjectName())
 	if err == nil {
 		this.ObjectData = obj.Data()
+		this.partial = obj.IsPartial()
 	}
 	return err
 }
@@ -48,7 +48,9 @@ func NewObject(data ObjectData, cluster Cluster, resource Internal) Object {
 
 func (this *_object) DeepCopy() Object {
 	data := this.ObjectData.DeepCopyObject().(ObjectData)
-	return NewObject(data, this.cluster, this.resource)
+	o := NewObject(data, this.cluster, this.resource).(*_object)
+	o.partial = this.partial
+	return o
 }
 
 /////////////////////////////////////////////////////////////////////////////////
@@ -61,6 +63,17 @@ func (this *_object) GetResource() Interface {
 	return this.resource
 }
 
+func (this *_object) IsMetadataOnly() bool {
+	return IsMetadataOnly(this.ObjectData)
+}
+
+func (this *_object) GetFullObject() (Object, error) {
+	if !this.IsMetadataOnly() {
+		return this, nil
+	}
+	return this.resource.Get_(this.ObjectName())
+}
+
 func (this *_object) IsA(spec interface{}) bool {
 	switch s := spec.(type) {
 	case GroupKindProvider:
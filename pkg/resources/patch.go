@@ -0,0 +1,77 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// CreateMergePatch computes a JSON merge patch (RFC 7386) transforming
+// the original object into the modified one.
+func CreateMergePatch(original, modified ObjectData) ([]byte, error) {
+	patch, err := mergePatch(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(patch)
+}
+
+func mergePatch(original, modified ObjectData) (map[string]interface{}, error) {
+	o, err := toJSONMap(original)
+	if err != nil {
+		return nil, err
+	}
+	m, err := toJSONMap(modified)
+	if err != nil {
+		return nil, err
+	}
+	return mergeDiff(o, m), nil
+}
+
+func toJSONMap(obj ObjectData) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+func mergeDiff(original, modified map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k := range original {
+		if _, ok := modified[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	for k, m := range modified {
+		o, ok := original[k]
+		if ok && reflect.DeepEqual(o, m) {
+			continue
+		}
+		om, ok1 := o.(map[string]interface{})
+		mm, ok2 := m.(map[string]interface{})
+		if ok1 && ok2 {
+			patch[k] = mergeDiff(om, mm)
+		} else {
+			patch[k] = m
+		}
+	}
+	return patch
+}
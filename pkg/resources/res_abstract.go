@@ -58,12 +58,31 @@ func (this *AbstractResource) Namespaced() bool {
 }
 
 func (this *AbstractResource) Wrap(obj ObjectData) (Object, error) {
-	if err := this.helper.CheckOType(obj); err != nil {
+	if err := this.checkWrap(obj); err != nil {
 		return nil, err
 	}
 	return this.helper.ObjectAsResource(obj), nil
 }
 
+// wrapCached wraps an object served by an informer cache
+// (see ResourceHelper.CachedObjectAsResource).
+func (this *AbstractResource) wrapCached(obj ObjectData) (Object, error) {
+	if err := this.checkWrap(obj); err != nil {
+		return nil, err
+	}
+	return this.helper.CachedObjectAsResource(obj), nil
+}
+
+func (this *AbstractResource) checkWrap(obj ObjectData) error {
+	if IsMetadataOnly(obj) {
+		if gk := obj.GetObjectKind().GroupVersionKind().GroupKind(); !gk.Empty() && gk != this.GroupKind() {
+			return fmt.Errorf("%s cannot handle group/kind '%s'", this.GroupVersionKind(), gk)
+		}
+		return nil
+	}
+	return this.helper.CheckOType(obj)
+}
+
 func (this *AbstractResource) New(name ObjectName) Object {
 	data := this.helper.CreateData()
 	data.GetObjectKind().SetGroupVersionKind(this.GroupVersionKind())
@@ -75,7 +94,7 @@ func (this *AbstractResource) New(name ObjectName) Object {
 }
 
 func (this *AbstractResource) Namespace(namespace string) Namespaced {
-	return &namespacedResource{this, namespace, nil}
+	return &namespacedResource{resource: this, namespace: namespace}
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -85,6 +104,15 @@ func (this *ResourceHelper) ObjectAsResource(obj ObjectData) Object {
 	return NewObject(obj, this.GetCluster(), this.Internal)
 }
 
+// CachedObjectAsResource wraps an object served by an informer cache.
+// For resources with cache transformations it is marked as partial
+// (see Object.IsPartial).
+func (this *ResourceHelper) CachedObjectAsResource(obj ObjectData) Object {
+	o := NewObject(obj, this.GetCluster(), this.Internal).(*_object)
+	o.partial = !IsMetadataOnly(obj) && len(this.ResourceContext().GetCacheTransforms(this.GroupKind())) > 0
+	return o
+}
+
 func (this *ResourceHelper) CreateData(name ...ObjectDataName) ObjectData {
 	data := reflect.New(this.I_objectType()).Interface().(ObjectData)
 	if u, ok := data.(*unstructured.Unstructured); ok {
@@ -22,9 +22,11 @@ import (
 	"runtime/debug"
 )
 
-func (this *_resource) getCached(namespace, name string) (Object, error) {
+type informerLookup func(namespace string) (GenericInformer, error)
+
+func (this *_resource) getCached(lookup informerLookup, namespace, name string) (Object, error) {
 	var obj ObjectData
-	informer, err := this.self.I_lookupInformer(namespace)
+	informer, err := lookup(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -42,40 +44,54 @@ func (this *_resource) getCached(namespace, name string) (Object, error) {
 	if err != nil {
 		return nil, err
 	}
-	return this.helper.ObjectAsResource(obj), nil
+	return this.helper.CachedObjectAsResource(obj), nil
 }
 
 func (this *_resource) GetCached(obj interface{}) (Object, error) {
-	switch o := obj.(type) {
-	case string:
-		return this.getCached("", o)
-	case ObjectData:
+	if o, ok := obj.(ObjectData); ok {
 		if err := this.helper.CheckOType(o); err != nil {
 			return nil, err
 		}
-		return this.helper.ObjectAsResource(o), nil
+		return this.helper.CachedObjectAsResource(o), nil
+	}
+	return this.getCachedByKey(this.self.I_lookupInformer, obj)
+}
+
+// GetCachedMetadata provides an object from the cache of a metadata informer,
+// which just contains the metadata of the object (see Object.IsMetadataOnly).
+func (this *_resource) GetCachedMetadata(obj interface{}) (Object, error) {
+	if o, ok := obj.(ObjectData); ok {
+		return this.getCached(this.self.I_lookupMetadataInformer, o.GetNamespace(), o.GetName())
+	}
+	return this.getCachedByKey(this.self.I_lookupMetadataInformer, obj)
+}
+
+func (this *_resource) getCachedByKey(lookup informerLookup, obj interface{}) (Object, error) {
+	switch o := obj.(type) {
+	case string:
+		return this.getCached(lookup, "", o)
 	case ObjectKey:
 		if o.GroupKind() != this.GroupKind() {
 			return nil, fmt.Errorf("%s cannot handle group/kind '%s'", this.gvk, o.GroupKind())
 		}
-		return this.getCached(o.Namespace(), o.Name())
+		return this.getCached(lookup, o.Namespace(), o.Name())
 	case *ObjectKey:
 		if o.GroupKind() != this.GroupKind() {
 			return nil, fmt.Errorf("%s cannot handle group/kind '%s'", this.gvk, o.GroupKind())
 		}
-		return this.getCached(o.Namespace(), o.Name())
+		return this.getCached(lookup, o.Namespace(), o.Name())
 	case ClusterObjectKey:
 		if o.GroupKind() != this.GroupKind() {
 			return nil, fmt.Errorf("%s cannot handle group/kind '%s'", this.gvk, o.GroupKind())
 		}
-		return this.getCached(o.Namespace(), o.Name())
+		return this.getCached(lookup, o.Namespace(), o.Name())
 	case *ClusterObjectKey:
 		if o.GroupKind() != this.GroupKind() {
 			return nil, fmt.Errorf("%s cannot handle group/kind '%s'", this.gvk, o.GroupKind())
 		}
-		return this.getCached(o.Namespace(), o.Name())
+		return this.getCached(lookup, o.Namespace(), o.Name())
 	case ObjectName:
-		return this.getCached(o.Namespace(), o.Name())
+		return this.getCached(lookup, o.Namespace(), o.Name())
 	default:
 		debug.PrintStack()
 		return nil, fmt.Errorf("unsupported type '%T' for source object", obj)
@@ -91,7 +107,35 @@ func (this *_resource) ListCached(selector labels.Selector) (ret []Object, err e
 		selector = labels.Everything()
 	}
 	err = informer.Lister().List(selector, func(obj interface{}) {
-		ret = append(ret, this.helper.ObjectAsResource(obj.(ObjectData)))
+		ret = append(ret, this.helper.CachedObjectAsResource(obj.(ObjectData)))
+	})
+	return ret, err
+}
+
+// ListCachedMetadata lists the objects cached by a metadata informer.
+func (this *_resource) ListCachedMetadata(selector labels.Selector) (ret []Object, err error) {
+	informer, err := this.self.I_getMetadataInformer("", nil)
+	if err != nil {
+		return nil, err
+	}
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	err = informer.Lister().List(selector, func(obj interface{}) {
+		ret = append(ret, this.helper.CachedObjectAsResource(obj.(ObjectData)))
+	})
+	return ret, err
+}
+
+// ListCachedByIndex lists the cached objects with the given value for
+// a cache index registered with AddCacheIndex.
+func (this *_resource) ListCachedByIndex(indexName, value string) (ret []Object, err error) {
+	informer, err := this.self.I_getInformer("", nil)
+	if err != nil {
+		return nil, err
+	}
+	err = informer.Lister().ListByIndex(indexName, value, func(obj interface{}) {
+		ret = append(ret, this.helper.CachedObjectAsResource(obj.(ObjectData)))
 	})
 	return ret, err
 }
@@ -109,6 +153,17 @@ func (this *namespacedResource) getLister() (NamespacedLister, error) {
 	return this.lister, nil
 }
 
+func (this *namespacedResource) getMetadataLister() (NamespacedLister, error) {
+	if this.metadataLister == nil {
+		informer, err := this.resource.self.I_lookupMetadataInformer(this.namespace)
+		if err != nil {
+			return nil, err
+		}
+		this.metadataLister = informer.Lister().Namespace(this.namespace)
+	}
+	return this.metadataLister, nil
+}
+
 func (this *namespacedResource) GetCached(name string) (ret Object, err error) {
 
 	lister, err := this.getLister()
@@ -119,7 +174,7 @@ func (this *namespacedResource) GetCached(name string) (ret Object, err error) {
 	if err != nil {
 		return nil, err
 	}
-	return this.resource.helper.ObjectAsResource(obj.(ObjectData)), nil
+	return this.resource.helper.CachedObjectAsResource(obj.(ObjectData)), nil
 }
 
 func (this *namespacedResource) ListCached(selector labels.Selector) (ret []Object, err error) {
@@ -131,7 +186,44 @@ func (this *namespacedResource) ListCached(selector labels.Selector) (ret []Obje
 		selector = labels.Everything()
 	}
 	err = lister.List(selector, func(obj interface{}) {
-		ret = append(ret, this.resource.helper.ObjectAsResource(obj.(ObjectData)))
+		ret = append(ret, this.resource.helper.CachedObjectAsResource(obj.(ObjectData)))
+	})
+	return ret, err
+}
+
+func (this *namespacedResource) GetCachedMetadata(name string) (ret Object, err error) {
+	lister, err := this.getMetadataLister()
+	if err != nil {
+		return nil, err
+	}
+	obj, err := lister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return this.resource.helper.CachedObjectAsResource(obj.(ObjectData)), nil
+}
+
+func (this *namespacedResource) ListCachedMetadata(selector labels.Selector) (ret []Object, err error) {
+	lister, err := this.getMetadataLister()
+	if err != nil {
+		return nil, err
+	}
+	if selector == nil {
+		selector = labels.Everything()
+	}
+	err = lister.List(selector, func(obj interface{}) {
+		ret = append(ret, this.resource.helper.CachedObjectAsResource(obj.(ObjectData)))
+	})
+	return ret, err
+}
+
+func (this *namespacedResource) ListCachedByIndex(indexName, value string) (ret []Object, err error) {
+	lister, err := this.getLister()
+	if err != nil {
+		return nil, err
+	}
+	err = lister.ListByIndex(indexName, value, func(obj interface{}) {
+		ret = append(ret, this.resource.helper.CachedObjectAsResource(obj.(ObjectData)))
 	})
 	return ret, err
 }
@@ -17,15 +17,24 @@
 package resources
 
 import (
+	"encoding/json"
+	"fmt"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/gardener/controller-manager-library/pkg/informerfactories"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	restclient "k8s.io/client-go/rest"
 )
 
 type Internal interface {
@@ -38,14 +47,25 @@ type Internal interface {
 	I_get(data ObjectData) error
 	I_update(data ObjectData) (ObjectData, error)
 	I_updateStatus(data ObjectData) (ObjectData, error)
+	I_apply(data ObjectData, status bool, fieldManager string, force bool) (ObjectData, error)
+	I_patch(name ObjectDataName, status bool, pt types.PatchType, data []byte) (ObjectData, error)
 	I_delete(data ObjectDataName) error
+	I_deleteCollection(namespace string, opts metav1.ListOptions, delOpts *metav1.DeleteOptions) error
+
+	I_getSubResource(name ObjectDataName, subresource string, result runtime.Object) error
+	I_updateSubResource(name ObjectDataName, subresource string, data, result runtime.Object) error
+	I_createSubResource(name ObjectDataName, subresource string, data, result runtime.Object) error
 
 	I_modifyByName(name ObjectDataName, status_only, create bool, modifier Modifier) (Object, bool, error)
 	I_modify(data ObjectData, status_only, read, create bool, modifier Modifier) (ObjectData, bool, error)
 
 	I_getInformer(namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error)
 	I_lookupInformer(namespace string) (GenericInformer, error)
+	I_getMetadataInformer(namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error)
+	I_lookupMetadataInformer(namespace string) (GenericInformer, error)
 	I_list(namespace string, opts metav1.ListOptions) ([]Object, error)
+	I_listPaged(namespace string, opts metav1.ListOptions, pageSize int64, consumer ObjectConsumer) error
+	I_watch(namespace string, opts metav1.ListOptions) (watch.Interface, error)
 }
 
 // _i_resource is the implementation of the internal resource interface used by
@@ -73,41 +93,168 @@ func (this *_i_resource) I_listType() reflect.Type {
 
 func (this *_i_resource) I_update(data ObjectData) (ObjectData, error) {
 	logger.Infof("UPDATE %s/%s/%s", this.GroupKind(), data.GetNamespace(), data.GetName())
+	old := this.dryRunCurrent(data)
 	result := this.helper.CreateData()
-	return result, this.objectRequest(this.client.Put(), data).
+	err := this.restoreKind(result, this.objectRequest(this.dryRun(this.client.Put()), data).
 		Body(data).
 		Do().
-		Into(result)
+		Into(result))
+	this.logDryRun("UPDATE", data, old, result, err)
+	return result, err
 }
 
 func (this *_i_resource) I_updateStatus(data ObjectData) (ObjectData, error) {
 	logger.Infof("UPDATE STATUS %s/%s/%s", this.GroupKind(), data.GetNamespace(), data.GetName())
+	old := this.dryRunCurrent(data)
 	result := this.helper.CreateData()
-	return result, this.objectRequest(this.client.Put(), data, "status").
+	err := this.restoreKind(result, this.objectRequest(this.dryRun(this.client.Put()), data, "status").
 		Body(data).
 		Do().
-		Into(result)
+		Into(result))
+	this.logDryRun("UPDATE STATUS", data, old, result, err)
+	return result, err
+}
+
+// I_apply sends the given object as configuration for a server side apply
+// request. The managed fields are cleared, because they must not be set
+// for apply requests.
+func (this *_i_resource) I_apply(data ObjectData, status bool, fieldManager string, force bool) (ObjectData, error) {
+	logger.Infof("APPLY %s/%s/%s", this.GroupKind(), data.GetNamespace(), data.GetName())
+	if fieldManager == "" {
+		fieldManager = this.Resources().FieldManager()
+	}
+	cfg := data.DeepCopyObject().(ObjectData)
+	cfg.GetObjectKind().SetGroupVersionKind(this.gvk)
+	cfg.SetManagedFields(nil)
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var sub []string
+	if status {
+		sub = append(sub, "status")
+	}
+	old := this.dryRunCurrent(data)
+	result := this.helper.CreateData()
+	err = this.restoreKind(result, this.objectRequest(this.dryRun(this.client.Patch(types.ApplyPatchType)), data, sub...).
+		VersionedParams(&metav1.PatchOptions{FieldManager: fieldManager, Force: &force}, this.context.Clients.parametercodec).
+		Body(body).
+		Do().
+		Into(result))
+	this.logDryRun("APPLY", data, old, result, err)
+	return result, err
+}
+
+func (this *_i_resource) I_patch(name ObjectDataName, status bool, pt types.PatchType, data []byte) (ObjectData, error) {
+	logger.Infof("PATCH %s/%s/%s", this.GroupKind(), name.GetNamespace(), name.GetName())
+	var sub []string
+	if status {
+		sub = append(sub, "status")
+	}
+	old := this.dryRunCurrent(name)
+	result := this.helper.CreateData()
+	err := this.restoreKind(result, this.objectRequest(this.dryRun(this.client.Patch(pt)), name, sub...).
+		Body(data).
+		Do().
+		Into(result))
+	this.logDryRun("PATCH", name, old, result, err)
+	return result, err
+}
+
+// checkSubResource assures that the resource offers the given subresource.
+func (this *_i_resource) checkSubResource(subresource string) error {
+	if !this.Info().HasSubResource(subresource) {
+		return fmt.Errorf("resource %s (%s) has no subresource '%s'", this.Name(), this.GroupVersionKind(), subresource)
+	}
+	return nil
+}
+
+// subResourceResult decodes the response of a subresource request into
+// the given result object, if any.
+func subResourceResult(r restclient.Result, result runtime.Object) error {
+	if result == nil {
+		return r.Error()
+	}
+	return r.Into(result)
+}
+
+func (this *_i_resource) I_getSubResource(name ObjectDataName, subresource string, result runtime.Object) error {
+	if err := this.checkSubResource(subresource); err != nil {
+		return err
+	}
+	return subResourceResult(this.objectRequest(this.client.Get(), name, subresource).
+		Do(), result)
+}
+
+func (this *_i_resource) I_updateSubResource(name ObjectDataName, subresource string, data, result runtime.Object) error {
+	if err := this.checkSubResource(subresource); err != nil {
+		return err
+	}
+	logger.Infof("UPDATE %s %s/%s/%s", strings.ToUpper(subresource), this.GroupKind(), name.GetNamespace(), name.GetName())
+	err := subResourceResult(this.objectRequest(this.dryRun(this.client.Put()), name, subresource).
+		Body(data).
+		Do(), result)
+	this.logDryRun("UPDATE "+strings.ToUpper(subresource), name, nil, nil, err)
+	return err
+}
+
+func (this *_i_resource) I_createSubResource(name ObjectDataName, subresource string, data, result runtime.Object) error {
+	if err := this.checkSubResource(subresource); err != nil {
+		return err
+	}
+	logger.Infof("CREATE %s %s/%s/%s", strings.ToUpper(subresource), this.GroupKind(), name.GetNamespace(), name.GetName())
+	err := subResourceResult(this.objectRequest(this.dryRun(this.client.Post()), name, subresource).
+		Body(data).
+		Do(), result)
+	this.logDryRun("CREATE "+strings.ToUpper(subresource), name, nil, nil, err)
+	return err
 }
 
 func (this *_i_resource) I_create(data ObjectData) (ObjectData, error) {
 	result := this.helper.CreateData()
-	return result, this.resourceRequest(this.client.Post(), data).
+	err := this.restoreKind(result, this.resourceRequest(this.dryRun(this.client.Post()), data).
 		Body(data).
 		Do().
-		Into(result)
+		Into(result))
+	this.logDryRun("CREATE", result, nil, result, err)
+	return result, err
 }
 
 func (this *_i_resource) I_get(data ObjectData) error {
-	return this.objectRequest(this.client.Get(), data).
+	return this.restoreKind(data, this.objectRequest(this.client.Get(), data).
 		Do().
-		Into(data)
+		Into(data))
+}
+
+// restoreKind sets the kind cleared by the decoder. It is required to
+// encode unstructured objects for subsequent requests.
+func (this *_i_resource) restoreKind(data ObjectData, err error) error {
+	if err == nil && this.IsUnstructured() {
+		data.GetObjectKind().SetGroupVersionKind(this.gvk)
+	}
+	return err
 }
 
 func (this *_i_resource) I_delete(data ObjectDataName) error {
-	return this.objectRequest(this.client.Delete(), data).
-		Body(&metav1.DeleteOptions{}).
+	err := this.objectRequest(this.client.Delete(), data).
+		Body(this.dryRunDeleteOptions(nil)).
+		Do().
+		Error()
+	this.logDryRun("DELETE", data, nil, nil, err)
+	return err
+}
+
+func (this *_i_resource) I_deleteCollection(namespace string, opts metav1.ListOptions, delOpts *metav1.DeleteOptions) error {
+	logger.Infof("DELETE COLLECTION %s/%s (%s)", this.GroupKind(), namespace, opts.LabelSelector)
+	err := this.namespacedRequest(this.client.Delete(), namespace).
+		VersionedParams(&opts, this.GetParameterCodec()).
+		Body(this.dryRunDeleteOptions(delOpts)).
 		Do().
 		Error()
+	if err == nil && this.context.dryRun {
+		logger.Infof("DRY-RUN DELETE COLLECTION %s/%s (%s)", this.GroupKind(), namespace, opts.LabelSelector)
+	}
+	return err
 }
 
 func (this *_i_resource) I_getInformer(namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error) {
@@ -165,6 +312,30 @@ func (this *_i_resource) I_lookupInformer(namespace string) (GenericInformer, er
 	return informer, nil
 }
 
+func (this *_i_resource) I_lookupMetadataInformer(namespace string) (GenericInformer, error) {
+	informers := this.context.SharedInformerFactory().Metadata()
+	informer, err := informers.LookupInformerFor(this.gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if err := informerfactories.Start(this.context.ctx, informers, informer.Informer().HasSynced); err != nil {
+		return nil, err
+	}
+	return informer, nil
+}
+
+func (this *_i_resource) I_getMetadataInformer(namespace string, optionsFunc TweakListOptionsFunc) (GenericInformer, error) {
+	informers := this.context.SharedInformerFactory().Metadata()
+	informer, err := this.context.SharedInformerFactory().FilteredMetadataInformerFor(this.gvk, namespace, optionsFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err := informerfactories.Start(this.context.ctx, informers, informer.Informer().HasSynced); err != nil {
+		return nil, err
+	}
+	return informer, nil
+}
+
 func (this *_i_resource) I_list(namespace string, options metav1.ListOptions) ([]Object, error) {
 	result := this.helper.CreateListData()
 	err := this.namespacedRequest(this.client.Get(), namespace).VersionedParams(&options, this.GetParameterCodec()).
@@ -176,6 +347,75 @@ func (this *_i_resource) I_list(namespace string, options metav1.ListOptions) ([
 	return this.handleList(result)
 }
 
+// I_watch starts a watch stopped together with the resource context.
+func (this *_i_resource) I_watch(namespace string, options metav1.ListOptions) (watch.Interface, error) {
+	if err := this.context.ctx.Err(); err != nil {
+		return nil, err
+	}
+	options.Watch = true
+	w, err := this.namespacedRequest(this.client.Get(), namespace).VersionedParams(&options, this.GetParameterCodec()).
+		Watch()
+	if err != nil {
+		return nil, err
+	}
+	return NewWatchWrapper(this.context.ctx, w), nil
+}
+
+// MAX_LIST_RESTARTS is the number of restarts of a paginated list after
+// its continue token expired.
+const MAX_LIST_RESTARTS = 3
+
+// I_listPaged lists the objects page by page. If the continue token of a
+// page is expired (410 Gone), the list is restarted. Objects already passed
+// to the consumer are then skipped.
+func (this *_i_resource) I_listPaged(namespace string, options metav1.ListOptions, pageSize int64, consumer ObjectConsumer) error {
+	initial := options
+	initial.Limit = pageSize
+	initial.Continue = ""
+	options = initial
+
+	passed := utils.StringSet{}
+	restarts := 0
+	for {
+		result := this.helper.CreateListData()
+		err := this.namespacedRequest(this.client.Get(), namespace).VersionedParams(&options, this.GetParameterCodec()).
+			Do().
+			Into(result)
+		if err != nil {
+			if options.Continue != "" && errors.IsResourceExpired(err) && restarts < MAX_LIST_RESTARTS {
+				restarts++
+				logger.Infof("list of %s expired: restarting (%d)", this.GroupKind(), restarts)
+				options = initial
+				continue
+			}
+			return err
+		}
+		list, err := this.handleList(result)
+		if err != nil {
+			return err
+		}
+		for _, o := range list {
+			key := o.ObjectName().String()
+			if passed.Contains(key) {
+				continue
+			}
+			passed.Add(key)
+			if err := consumer(o); err != nil {
+				return err
+			}
+		}
+		l, err := meta.ListAccessor(result)
+		if err != nil {
+			return err
+		}
+		if l.GetContinue() == "" {
+			return nil
+		}
+		options.Continue = l.GetContinue()
+		options.ResourceVersion = ""
+	}
+}
+
 func (this *_i_resource) I_modifyByName(name ObjectDataName, status_only, create bool, modifier Modifier) (Object, bool, error) {
 	data := this.helper.CreateData()
 	data.SetName(name.GetName())
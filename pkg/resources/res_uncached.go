@@ -19,10 +19,14 @@ package resources
 import (
 	"fmt"
 	"reflect"
+	"sync"
 
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 func (this *AbstractResource) Create(obj ObjectData) (Object, error) {
@@ -85,6 +89,59 @@ func (this *AbstractResource) Update(obj ObjectData) (Object, error) {
 	return this.helper.ObjectAsResource(result), nil
 }
 
+// Apply applies the given object with a server side apply request. The
+// object may be given as unstructured object containing only the fields
+// managed by the field manager. If no field manager is given, the default
+// field manager of the Resources is used.
+func (this *AbstractResource) Apply(obj ObjectData, fieldManager string, force bool) (Object, error) {
+	return this.apply(obj, false, fieldManager, force)
+}
+
+// ApplyStatus applies the status of the given object.
+func (this *AbstractResource) ApplyStatus(obj ObjectData, fieldManager string, force bool) (Object, error) {
+	if !this.self.Info().HasStatusSubResource() {
+		return nil, fmt.Errorf("resource %q has no status sub resource", this.GroupVersionKind())
+	}
+	return this.apply(obj, true, fieldManager, force)
+}
+
+func (this *AbstractResource) apply(obj ObjectData, status bool, fieldManager string, force bool) (Object, error) {
+	if o, ok := obj.(Object); ok {
+		obj = o.Data()
+	}
+	if !IsMetadataOnly(obj) {
+		if err := this.helper.CheckOType(obj, true); err != nil {
+			return nil, err
+		}
+	}
+	result, err := this.self.I_apply(obj, status, fieldManager, force)
+	if err != nil {
+		return nil, err
+	}
+	return this.helper.ObjectAsResource(result), nil
+}
+
+// Patch sends a JSON patch, a JSON merge patch or a strategic merge patch
+// for the object with the given name.
+func (this *AbstractResource) Patch(name ObjectDataName, pt types.PatchType, data []byte) (Object, error) {
+	result, err := this.self.I_patch(name, false, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return this.helper.ObjectAsResource(result), nil
+}
+
+func (this *AbstractResource) PatchStatus(name ObjectDataName, pt types.PatchType, data []byte) (Object, error) {
+	if !this.self.Info().HasStatusSubResource() {
+		return nil, fmt.Errorf("resource %q has no status sub resource", this.GroupVersionKind())
+	}
+	result, err := this.self.I_patch(name, true, pt, data)
+	if err != nil {
+		return nil, err
+	}
+	return this.helper.ObjectAsResource(result), nil
+}
+
 func (this *AbstractResource) Modify(obj ObjectData, modifier Modifier) (ObjectData, bool, error) {
 	if o, ok := obj.(Object); ok {
 		obj = o.Data()
@@ -106,7 +163,7 @@ func (this *AbstractResource) ModifyStatus(obj ObjectData, modifier Modifier) (O
 	if err := this.helper.CheckOType(obj); err != nil {
 		return nil, false, err
 	}
-	return this.self.I_modify(obj, false, false, false, modifier)
+	return this.self.I_modify(obj, true, false, false, modifier)
 }
 
 func (this *AbstractResource) ModifyStatusByName(obj ObjectDataName, modifier Modifier) (Object, bool, error) {
@@ -131,6 +188,72 @@ func (this *AbstractResource) DeleteByName(obj ObjectDataName) error {
 	return this.self.I_delete(obj)
 }
 
+// DeleteCollection deletes all objects of a namespace (for cluster scoped
+// resources all objects) matching the label selector with a single request.
+// The API server does not support collections over all namespaces, and
+// to delete all objects the selector must explicitly be labels.Everything().
+// The options may specify the propagation policy and preconditions for the
+// objects.
+func (this *AbstractResource) DeleteCollection(namespace string, selector labels.Selector, options *metav1.DeleteOptions) error {
+	if this.Namespaced() && namespace == "" {
+		return fmt.Errorf("%s is namespaced, a namespace is required", this.GroupKind())
+	}
+	if !this.Namespaced() && namespace != "" {
+		return fmt.Errorf("%s is not namespaced", this.GroupKind())
+	}
+	if selector == nil {
+		return fmt.Errorf("no selector for deletion of %s (use labels.Everything() to delete all objects)", this.GroupKind())
+	}
+	opts := metav1.ListOptions{LabelSelector: selector.String()}
+	return this.self.I_deleteCollection(namespace, opts, options)
+}
+
+// MODIFY_ALL_CONCURRENCY is the maximum number of parallel modifications
+// of ModifyAll.
+const MODIFY_ALL_CONCURRENCY = 10
+
+// ModifyAll applies a modifier to all objects matching the label selector.
+// The objects are modified concurrently, therefore the modifier must be
+// able to be called in parallel. Objects deleted meanwhile are ignored.
+// It returns the number of modified objects and the aggregated errors.
+func (this *AbstractResource) ModifyAll(selector labels.Selector, modifier Modifier) (int, error) {
+	opts := metav1.ListOptions{}
+	if selector != nil {
+		opts.LabelSelector = selector.String()
+	}
+	list, err := this.self.I_list(metav1.NamespaceAll, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	var errs []error
+	count := 0
+	limit := make(chan struct{}, MODIFY_ALL_CONCURRENCY)
+	for _, o := range list {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(o Object) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			_, mod, err := this.self.I_modify(o.Data(), false, false, false, modifier)
+			lock.Lock()
+			defer lock.Unlock()
+			switch {
+			case err != nil && !k8serr.IsNotFound(err):
+				errs = append(errs, fmt.Errorf("%s: %s", o.ObjectName(), err))
+			case err == nil && mod:
+				count++
+			}
+		}(o)
+	}
+	wg.Wait()
+	return count, utilerrors.NewAggregate(errs)
+}
+
 func (this *AbstractResource) handleList(result runtime.Object) (ret []Object, err error) {
 	v := reflect.ValueOf(result)
 	iv := v.Elem().FieldByName("Items")
@@ -197,6 +320,14 @@ func (this *AbstractResource) List(opts metav1.ListOptions) (ret []Object, err e
 	return this.self.I_list(metav1.NamespaceAll, opts)
 }
 
+// ListPaged lists the objects with requests for pages of the given size
+// to limit the load for the API server and the memory required for large
+// lists. If the list expires before it is complete, it is restarted,
+// objects already passed to the consumer are skipped.
+func (this *AbstractResource) ListPaged(opts metav1.ListOptions, pageSize int64, consumer ObjectConsumer) error {
+	return this.self.I_listPaged(metav1.NamespaceAll, opts, pageSize, consumer)
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (this *namespacedResource) GetInto(name string, obj ObjectData) (ret Object, err error) {
@@ -219,3 +350,85 @@ func (this *namespacedResource) List(opts metav1.ListOptions) (ret []Object, err
 	}
 	return this.resource.self.I_list(this.namespace, opts)
 }
+
+func (this *namespacedResource) ListPaged(opts metav1.ListOptions, pageSize int64, consumer ObjectConsumer) error {
+	if !this.resource.Namespaced() {
+		return fmt.Errorf("resourcename %s (%s) is not namespaced", this.resource.Name(), this.resource.GroupVersionKind())
+	}
+	return this.resource.self.I_listPaged(this.namespace, opts, pageSize, consumer)
+}
+
+// checkObject assures that an object belongs to the namespace of the view.
+// Objects without namespace are assigned to it.
+func (this *namespacedResource) checkObject(obj ObjectData) (ObjectData, error) {
+	if o, ok := obj.(Object); ok {
+		obj = o.Data()
+	}
+	if !this.resource.Namespaced() {
+		return nil, fmt.Errorf("resourcename %s (%s) is not namespaced", this.resource.Name(), this.resource.GroupVersionKind())
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(this.namespace)
+	}
+	if obj.GetNamespace() != this.namespace {
+		return nil, fmt.Errorf("%s %q is not in namespace %q", this.resource.GroupKind(), obj.GetNamespace()+"/"+obj.GetName(), this.namespace)
+	}
+	return obj, nil
+}
+
+func (this *namespacedResource) Create(obj ObjectData) (Object, error) {
+	obj, err := this.checkObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return this.resource.Create(obj)
+}
+
+func (this *namespacedResource) Update(obj ObjectData) (Object, error) {
+	obj, err := this.checkObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return this.resource.Update(obj)
+}
+
+func (this *namespacedResource) Modify(obj ObjectData, modifier Modifier) (ObjectData, bool, error) {
+	obj, err := this.checkObject(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return this.resource.Modify(obj, modifier)
+}
+
+func (this *namespacedResource) ModifyStatus(obj ObjectData, modifier Modifier) (ObjectData, bool, error) {
+	obj, err := this.checkObject(obj)
+	if err != nil {
+		return nil, false, err
+	}
+	return this.resource.ModifyStatus(obj, modifier)
+}
+
+func (this *namespacedResource) Delete(obj ObjectData) error {
+	obj, err := this.checkObject(obj)
+	if err != nil {
+		return err
+	}
+	return this.resource.Delete(obj)
+}
+
+func (this *namespacedResource) DeleteByName(name string) error {
+	if !this.resource.Namespaced() {
+		return fmt.Errorf("resourcename %s (%s) is not namespaced", this.resource.Name(), this.resource.GroupVersionKind())
+	}
+	return this.resource.DeleteByName(NewObjectName(this.namespace, name))
+}
+
+// DeleteCollection deletes all objects of the namespace matching the
+// given label selector. To delete all objects the selector must explicitly
+// be labels.Everything().
+func (this *namespacedResource) DeleteCollection(selector labels.Selector) error {
+	if !this.resource.Namespaced() {
+		return fmt.Errorf("resourcename %s (%s) is not namespaced", this.resource.Name(), this.resource.GroupVersionKind())
+	}
+	return this.resource.DeleteCollection(this.namespace, selector, nil)
+}
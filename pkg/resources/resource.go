@@ -17,6 +17,8 @@
 package resources
 
 import (
+	"context"
+
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"reflect"
@@ -32,24 +34,27 @@ import (
 
 type _resource struct {
 	AbstractResource
-	context *resourceContext
-	gvk     schema.GroupVersionKind
-	otype   reflect.Type
-	ltype   reflect.Type
-	info    *Info
-	client  restclient.Interface
+	context   *resourceContext
+	resources *_resources
+	gvk       schema.GroupVersionKind
+	otype     reflect.Type
+	ltype     reflect.Type
+	info      *Info
+	client    restclient.Interface
 }
 
 var _ Interface = &_resource{}
 
 type namespacedResource struct {
-	resource  *AbstractResource
-	namespace string
-	lister    NamespacedLister
+	resource       *AbstractResource
+	namespace      string
+	lister         NamespacedLister
+	metadataLister NamespacedLister
 }
 
 func newResource(
 	context *resourceContext,
+	resources *_resources,
 	otype reflect.Type,
 	ltype reflect.Type,
 	info *Info,
@@ -58,6 +63,7 @@ func newResource(
 	r := &_resource{
 		AbstractResource: AbstractResource{},
 		context:          context,
+		resources:        resources,
 		gvk:              info.GroupVersionKind(),
 		otype:            otype,
 		ltype:            ltype,
@@ -75,7 +81,7 @@ func (this *_resource) GetCluster() Cluster {
 }
 
 func (this *_resource) Resources() Resources {
-	return this.context.Resources()
+	return this.resources
 }
 
 var unstructuredType = reflect.TypeOf(unstructured.Unstructured{})
@@ -124,6 +130,53 @@ func (this *_resource) AddSelectedEventHandler(handlers ResourceEventHandlerFunc
 	return this.AddRawSelectedEventHandler(*convert(this, &handlers), namespace, optionsFunc)
 }
 
+// AddCacheTransforms registers functions transforming the objects of
+// this resource before they are cached by the informers of the cluster.
+// Registering functions under an already used name again is a no-op.
+func (this *_resource) AddCacheTransforms(name string, funcs ...TransformFunc) {
+	this.context.AddCacheTransforms(this.GroupKind(), name, funcs...)
+}
+
+// AddCacheIndex registers a named index for the informer caches of this
+// resource, which can be queried with ListCachedByIndex.
+func (this *_resource) AddCacheIndex(name string, index Index) error {
+	return this.context.AddCacheIndex(this.GroupKind(), name, index)
+}
+
+// AddMetadataEventHandler adds an event handler for a watch caching only
+// the metadata of the objects. The objects passed to the handler just
+// provide the metadata (see Object.IsMetadataOnly).
+func (this *_resource) AddMetadataEventHandler(handlers ResourceEventHandlerFuncs) error {
+	return this.AddSelectedMetadataEventHandler(handlers, "", nil)
+}
+
+func (this *_resource) AddSelectedMetadataEventHandler(handlers ResourceEventHandlerFuncs, namespace string, optionsFunc TweakListOptionsFunc) error {
+	logger.Infof("adding metadata watch for %s", this.gvk)
+	informer, err := this.self.I_getMetadataInformer(namespace, optionsFunc)
+	if err != nil {
+		return err
+	}
+	informer.AddEventHandler(convert(this, &handlers))
+	return nil
+}
+
+func (this *_resource) AddRemovableEventHandler(ctx context.Context, handlers ResourceEventHandlerFuncs, namespace string, optionsFunc TweakListOptionsFunc, metadata bool) error {
+	var informer GenericInformer
+	var err error
+	if metadata {
+		logger.Infof("adding removable metadata watch for %s", this.gvk)
+		informer, err = this.self.I_getMetadataInformer(namespace, optionsFunc)
+	} else {
+		logger.Infof("adding removable watch for %s", this.gvk)
+		informer, err = this.self.I_getInformer(namespace, optionsFunc)
+	}
+	if err != nil {
+		return err
+	}
+	informer.AddRemovableEventHandler(ctx, convert(this, &handlers))
+	return nil
+}
+
 func (this *_resource) NormalEventf(name ObjectDataName, reason, msgfmt string, args ...interface{}) {
 	this.Resources().Eventf(this.helper.CreateData(name), v1.EventTypeNormal, reason, msgfmt, args...)
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/logger"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -43,6 +44,8 @@ type _resources struct {
 	unstructuredHandlersByGroupVersionKind map[schema.GroupVersionKind]Interface
 
 	record.EventRecorder
+	source       string
+	fieldManager string
 }
 
 var _ Resources = &_resources{}
@@ -50,6 +53,7 @@ var _ Resources = &_resources{}
 func newResources(c *resourceContext, source string) *_resources {
 	res := _resources{}
 	res.ctx = c
+	res.source = source
 	res.informers = c.sharedInformerFactory
 	res.handlersByObjType = map[reflect.Type]Interface{}
 	res.handlersByGroupKind = map[schema.GroupKind]Interface{}
@@ -61,17 +65,47 @@ func newResources(c *resourceContext, source string) *_resources {
 	client, _ := c.GetClient(schema.GroupVersion{"", "v1"})
 
 	eventBroadcaster := record.NewBroadcaster()
-	eventBroadcaster.StartLogging(logger.Debugf)
-	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: typedcorev1.New(client).Events("")})
+	if c.dryRun {
+		// events are not recorded in dry-run mode
+		eventBroadcaster.StartLogging(func(format string, args ...interface{}) {
+			logger.Infof("DRY-RUN EVENT "+format, args...)
+		})
+	} else {
+		eventBroadcaster.StartLogging(logger.Debugf)
+		eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: typedcorev1.New(client).Events("")})
+	}
 	res.EventRecorder = eventBroadcaster.NewRecorder(c.scheme, corev1.EventSource{Component: source})
 
 	return &res
 }
 
+// view provides a view of the resources using another default field
+// manager. It shares the event recorder, but uses own resource objects,
+// which refer to the view.
+func (this *_resources) view(fieldManager string) *_resources {
+	res := _resources{}
+	res.ctx = this.ctx
+	res.source = this.source
+	res.fieldManager = fieldManager
+	res.informers = this.informers
+	res.EventRecorder = this.EventRecorder
+	res.handlersByObjType = map[reflect.Type]Interface{}
+	res.handlersByGroupKind = map[schema.GroupKind]Interface{}
+	res.handlersByGroupVersionKind = map[schema.GroupVersionKind]Interface{}
+
+	res.unstructuredHandlersByGroupKind = map[schema.GroupKind]Interface{}
+	res.unstructuredHandlersByGroupVersionKind = map[schema.GroupVersionKind]Interface{}
+	return &res
+}
+
 func (this *_resources) Resources() Resources {
 	return this
 }
 
+func (this *_resources) ResourceContext() ResourceContext {
+	return this.ctx
+}
+
 func (this *_resources) Get(spec interface{}) (Interface, error) {
 	switch o := spec.(type) {
 	case GroupKindProvider:
@@ -117,6 +151,29 @@ func (this *_resources) CreateOrUpdateObject(obj ObjectData) (Object, error) {
 	return r.CreateOrUpdate(obj)
 }
 
+// ApplyObject applies an object with a server side apply request.
+// Unstructured objects are applied using their group version kind.
+func (this *_resources) ApplyObject(obj ObjectData, fieldManager string, force bool) (Object, error) {
+	var r Interface
+	var err error
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		r, err = this.GetUnstructuredByGVK(u.GroupVersionKind())
+	} else {
+		r, err = this.GetByExample(obj)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.Apply(obj, fieldManager, force)
+}
+
+func (this *_resources) FieldManager() string {
+	if this.fieldManager != "" {
+		return this.fieldManager
+	}
+	return this.source
+}
+
 func (this *_resources) DeleteObject(obj ObjectData) error {
 	r, err := this.GetByExample(obj)
 	if err != nil {
@@ -261,7 +318,13 @@ func (this *_resources) getUnstructuredResource(info *Info) (Interface, error) {
 }
 
 func (this *_resources) Wrap(obj ObjectData) (Object, error) {
-	h, err := this.GetByExample(obj)
+	var h Interface
+	var err error
+	if IsMetadataOnly(obj) {
+		h, err = this.GetByGVK(obj.GetObjectKind().GroupVersionKind())
+	} else {
+		h, err = this.GetByExample(obj)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -311,6 +374,6 @@ func (r *_resources) newResource(gvk schema.GroupVersionKind, otype reflect.Type
 		return nil, fmt.Errorf("cannot determine list type for %s", otype)
 	}
 
-	handler := newResource(r.ctx, otype, ltype, info, client)
+	handler := newResource(r.ctx, r, otype, ltype, info, client)
 	return handler, nil
 }
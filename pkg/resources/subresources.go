@@ -0,0 +1,40 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// GetSubResource reads a subresource of an object into the given result
+// object. For subresources of aggregated APIs without a type known by the
+// scheme an unstructured object can be used.
+func (this *AbstractResource) GetSubResource(name ObjectDataName, subresource string, result runtime.Object) error {
+	return this.self.I_getSubResource(name, subresource, result)
+}
+
+// UpdateSubResource updates a subresource of an object. The response is
+// decoded into the result object, if given.
+func (this *AbstractResource) UpdateSubResource(name ObjectDataName, subresource string, data, result runtime.Object) error {
+	return this.self.I_updateSubResource(name, subresource, data, result)
+}
+
+// CreateSubResource creates a subresource of an object, like the eviction
+// of a pod. The response is decoded into the result object, if given.
+func (this *AbstractResource) CreateSubResource(name ObjectDataName, subresource string, data, result runtime.Object) error {
+	return this.self.I_createSubResource(name, subresource, data, result)
+}
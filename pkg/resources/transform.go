@@ -0,0 +1,138 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// TransformFunc modifies objects before they are stored in an informer
+// cache. It is used to strip fields never read from cached objects to
+// reduce the memory required for the caches.
+//
+// Objects provided by such a cache are partial. They must not be used
+// to update the object, because this would remove the stripped fields.
+// The complete object must be read from the cluster instead.
+type TransformFunc func(obj ObjectData)
+
+// StripManagedFields removes the managed fields and the last applied
+// configuration annotation of kubectl from an object.
+func StripManagedFields(obj ObjectData) {
+	obj.SetManagedFields(nil)
+	StripAnnotations(corev1.LastAppliedConfigAnnotation)(obj)
+}
+
+// StripAnnotations provides a TransformFunc removing the given annotations.
+func StripAnnotations(names ...string) TransformFunc {
+	return func(obj ObjectData) {
+		annos := obj.GetAnnotations()
+		if annos == nil {
+			return
+		}
+		found := false
+		for _, n := range names {
+			if _, ok := annos[n]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return
+		}
+		copy := map[string]string{}
+		for k, v := range annos {
+			copy[k] = v
+		}
+		for _, n := range names {
+			delete(copy, n)
+		}
+		obj.SetAnnotations(copy)
+	}
+}
+
+type cacheTransforms struct {
+	lock       sync.RWMutex
+	names      map[schema.GroupKind]map[string]bool
+	transforms map[schema.GroupKind][]TransformFunc
+}
+
+// AddCacheTransforms registers functions to transform the objects of a
+// resource before they are cached. They should be registered before
+// the informers for the resource are started. They apply to all informers
+// of the resource shared in a cluster, including metadata informers.
+// The functions are registered under a name, for example the name of
+// the controller using them. Registering functions under an already
+// used name again is a no-op.
+func (this *cacheTransforms) AddCacheTransforms(gk schema.GroupKind, name string, funcs ...TransformFunc) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.transforms == nil {
+		this.names = map[schema.GroupKind]map[string]bool{}
+		this.transforms = map[schema.GroupKind][]TransformFunc{}
+	}
+	names := this.names[gk]
+	if names == nil {
+		names = map[string]bool{}
+		this.names[gk] = names
+	}
+	if names[name] {
+		return
+	}
+	names[name] = true
+	this.transforms[gk] = append(append([]TransformFunc{}, this.transforms[gk]...), funcs...)
+}
+
+func (this *cacheTransforms) GetCacheTransforms(gk schema.GroupKind) []TransformFunc {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.transforms[gk]
+}
+
+func (this *cacheTransforms) transform(gk schema.GroupKind, obj runtime.Object) {
+	transforms := this.GetCacheTransforms(gk)
+	if len(transforms) == 0 {
+		return
+	}
+	apply := func(o runtime.Object) error {
+		if data, ok := o.(ObjectData); ok {
+			for _, t := range transforms {
+				t(data)
+			}
+		}
+		return nil
+	}
+	if meta.IsListType(obj) {
+		meta.EachListItem(obj, apply)
+	} else {
+		apply(obj)
+	}
+}
+
+func (this *cacheTransforms) transformWatch(gk schema.GroupKind, w watch.Interface) watch.Interface {
+	return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+		if in.Type != watch.Error {
+			this.transform(gk, in.Object)
+		}
+		return in, true
+	})
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	api "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(policy.SchemeBuilder)
+}
+
+// EvictPod evicts a pod using its eviction subresource. Unlike a plain
+// deletion, an eviction is rejected if it violates a pod disruption budget.
+func EvictPod(src ResourcesSource, name ObjectDataName, options *metav1.DeleteOptions) error {
+	resource, err := src.Resources().Get(&api.Pod{})
+	if err != nil {
+		return err
+	}
+	eviction := &policy.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Namespace: name.GetNamespace(), Name: name.GetName()},
+		DeleteOptions: options,
+	}
+	return resource.CreateSubResource(name, "eviction", eviction, nil)
+}
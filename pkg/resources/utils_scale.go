@@ -0,0 +1,58 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	autoscaling "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func init() {
+	Register(autoscaling.SchemeBuilder)
+}
+
+// GetScale reads the scale subresource of an object.
+func GetScale(resource Interface, name ObjectDataName) (*autoscaling.Scale, error) {
+	scale := &autoscaling.Scale{}
+	err := resource.GetSubResource(name, "scale", scale)
+	if err != nil {
+		return nil, err
+	}
+	return scale, nil
+}
+
+// UpdateScale updates the scale subresource of an object. If the scale
+// has a resource version, the update fails if the object has been
+// changed meanwhile.
+func UpdateScale(resource Interface, scale *autoscaling.Scale) (*autoscaling.Scale, error) {
+	result := &autoscaling.Scale{}
+	err := resource.UpdateSubResource(scale, "scale", scale, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetReplicas sets the desired number of replicas of an object using its
+// scale subresource.
+func SetReplicas(resource Interface, name ObjectDataName, replicas int32) (*autoscaling.Scale, error) {
+	scale := &autoscaling.Scale{
+		ObjectMeta: metav1.ObjectMeta{Namespace: name.GetNamespace(), Name: name.GetName()},
+		Spec:       autoscaling.ScaleSpec{Replicas: replicas},
+	}
+	return UpdateScale(resource, scale)
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package resources
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ObjectEvent is a watch event for a resource object. Error events
+// provide the error reported by the API server instead of an object.
+type ObjectEvent struct {
+	Type   watch.EventType
+	Object Object
+	Error  error
+}
+
+// ObjectWatch is a watch providing resource objects. It is stopped
+// together with the resource context.
+type ObjectWatch interface {
+	Stop()
+	ResultChan() <-chan ObjectEvent
+}
+
+// ObjectCondition is used to wait for an object. It is called with nil
+// if the object does not exist.
+type ObjectCondition func(obj Object) (bool, error)
+
+type objectWatch struct {
+	resource *AbstractResource
+	orig     watch.Interface
+	result   chan ObjectEvent
+	stop     chan struct{}
+	once     sync.Once
+}
+
+var _ ObjectWatch = &objectWatch{}
+
+func newObjectWatch(resource *AbstractResource, orig watch.Interface) ObjectWatch {
+	w := &objectWatch{resource: resource, orig: orig, result: make(chan ObjectEvent), stop: make(chan struct{})}
+	go w.run()
+	return w
+}
+
+func (this *objectWatch) Stop() {
+	this.once.Do(func() {
+		close(this.stop)
+		this.orig.Stop()
+	})
+}
+
+func (this *objectWatch) ResultChan() <-chan ObjectEvent {
+	return this.result
+}
+
+func (this *objectWatch) run() {
+	defer close(this.result)
+	for e := range this.orig.ResultChan() {
+		select {
+		case this.result <- this.convert(e):
+		case <-this.stop:
+			return
+		}
+	}
+}
+
+func (this *objectWatch) convert(e watch.Event) ObjectEvent {
+	if e.Type == watch.Error {
+		return ObjectEvent{Type: e.Type, Error: errors.FromObject(e.Object)}
+	}
+	data, ok := e.Object.(ObjectData)
+	if !ok {
+		return ObjectEvent{Type: watch.Error, Error: fmt.Errorf("unexpected object type %T for %s watch", e.Object, this.resource.GroupKind())}
+	}
+	if this.resource.self.IsUnstructured() {
+		if _, ok := data.(*unstructured.Unstructured); !ok {
+			// the decoder provides typed objects for kinds known by the scheme
+			m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(data)
+			if err != nil {
+				return ObjectEvent{Type: watch.Error, Error: err}
+			}
+			data = &unstructured.Unstructured{Object: m}
+		}
+		data.GetObjectKind().SetGroupVersionKind(this.resource.GroupVersionKind())
+	}
+	return ObjectEvent{Type: e.Type, Object: this.resource.helper.ObjectAsResource(data)}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Watch watches the objects of a namespace (or all namespaces for an
+// empty namespace) selected by the list options.
+func (this *AbstractResource) Watch(namespace string, opts metav1.ListOptions) (ObjectWatch, error) {
+	w, err := this.self.I_watch(namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+	return newObjectWatch(this, w), nil
+}
+
+// WaitFor waits until the condition is met for an object. If the watch
+// ends before, the object is read again and the watch is restarted.
+// On timeout the last seen state of the object is returned together with
+// wait.ErrWaitTimeout.
+func (this *AbstractResource) WaitFor(name ObjectDataName, condition ObjectCondition, timeout time.Duration) (Object, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	opts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", name.GetName()).String(),
+	}
+	for {
+		obj, err := this.helper.Get(name.GetNamespace(), name.GetName(), nil)
+		opts.ResourceVersion = ""
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			obj = nil
+		} else {
+			opts.ResourceVersion = obj.GetResourceVersion()
+		}
+		if ok, err := condition(obj); ok || err != nil {
+			return obj, err
+		}
+
+		w, err := this.Watch(name.GetNamespace(), opts)
+		if err != nil {
+			return obj, err
+		}
+		obj, done, err := this.waitForEvent(w, obj, condition, timer.C)
+		w.Stop()
+		if done || err != nil {
+			return obj, err
+		}
+	}
+}
+
+// waitForEvent checks the condition for the events of a watch. It returns
+// without being done if the watch ends.
+func (this *AbstractResource) waitForEvent(w ObjectWatch, obj Object, condition ObjectCondition, timeout <-chan time.Time) (Object, bool, error) {
+	for {
+		select {
+		case <-timeout:
+			return obj, true, wait.ErrWaitTimeout
+		case e, ok := <-w.ResultChan():
+			if !ok || e.Type == watch.Error {
+				return obj, false, nil
+			}
+			switch e.Type {
+			case watch.Added, watch.Modified:
+				obj = e.Object
+			case watch.Deleted:
+				obj = nil
+			default:
+				continue
+			}
+			if ok, err := condition(obj); ok || err != nil {
+				return obj, true, err
+			}
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *namespacedResource) Watch(opts metav1.ListOptions) (ObjectWatch, error) {
+	if !this.resource.Namespaced() {
+		return nil, fmt.Errorf("resourcename %s (%s) is not namespaced", this.resource.Name(), this.resource.GroupVersionKind())
+	}
+	return this.resource.Watch(this.namespace, opts)
+}
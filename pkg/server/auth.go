@@ -0,0 +1,157 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	authv1 "k8s.io/api/authentication/v1"
+	authclient "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	restclient "k8s.io/client-go/rest"
+)
+
+// Authenticator authenticates a request. It returns the
+// authenticated user and whether the authentication succeeded.
+type Authenticator interface {
+	Authenticate(r *http.Request) (string, bool, error)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type unionAuthenticator []Authenticator
+
+// NewUnionAuthenticator provides an authenticator accepting
+// a request, if one of the given authenticators accepts it.
+func NewUnionAuthenticator(auths ...Authenticator) Authenticator {
+	return unionAuthenticator(auths)
+}
+
+func (this unionAuthenticator) Authenticate(r *http.Request) (string, bool, error) {
+	var errs []string
+	for _, a := range this {
+		user, ok, err := a.Authenticate(r)
+		if ok {
+			return user, true, nil
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return "", false, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return "", false, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type clientCertAuthenticator struct{}
+
+// NewClientCertAuthenticator accepts requests with a client certificate
+// verified by the TLS layer (see Config.ClientCAs). The common name
+// of the certificate is used as user.
+func NewClientCertAuthenticator() Authenticator {
+	return clientCertAuthenticator{}
+}
+
+func (this clientCertAuthenticator) Authenticate(r *http.Request) (string, bool, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return "", false, nil
+	}
+	return r.TLS.VerifiedChains[0][0].Subject.CommonName, true, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+const TOKEN_CACHE_TTL = time.Minute
+
+type tokenEntry struct {
+	user    string
+	ok      bool
+	expires time.Time
+}
+
+type tokenReviewAuthenticator struct {
+	client authclient.TokenReviewInterface
+	lock   sync.Mutex
+	cache  map[string]tokenEntry
+}
+
+// NewTokenReviewAuthenticator accepts requests with a bearer token
+// validated by a TokenReview on the cluster given by the rest config.
+// Results are cached for a short period of time.
+func NewTokenReviewAuthenticator(cfg *restclient.Config) (Authenticator, error) {
+	client, err := authclient.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenReviewAuthenticator{
+		client: client.TokenReviews(),
+		cache:  map[string]tokenEntry{},
+	}, nil
+}
+
+func (this *tokenReviewAuthenticator) Authenticate(r *http.Request) (string, bool, error) {
+	auth := strings.TrimSpace(r.Header.Get("Authorization"))
+	parts := strings.SplitN(auth, " ", 2)
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return "", false, nil
+	}
+	token := strings.TrimSpace(parts[1])
+	if token == "" {
+		return "", false, nil
+	}
+
+	now := time.Now()
+	this.lock.Lock()
+	e, found := this.cache[token]
+	this.lock.Unlock()
+	if found && now.Before(e.expires) {
+		return e.user, e.ok, nil
+	}
+
+	review := &authv1.TokenReview{
+		Spec: authv1.TokenReviewSpec{Token: token},
+	}
+	result, err := this.client.Create(review)
+	if err != nil {
+		return "", false, fmt.Errorf("token review failed: %s", err)
+	}
+	e = tokenEntry{
+		user:    result.Status.User.Username,
+		ok:      result.Status.Authenticated,
+		expires: now.Add(TOKEN_CACHE_TTL),
+	}
+
+	this.lock.Lock()
+	for k, v := range this.cache {
+		if now.After(v.expires) {
+			delete(this.cache, k)
+		}
+	}
+	this.cache[token] = e
+	this.lock.Unlock()
+
+	if !e.ok && result.Status.Error != "" {
+		return "", false, fmt.Errorf("%s", result.Status.Error)
+	}
+	return e.user, e.ok, nil
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package server
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/cert"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// CertificateSource provides the server certificate for TLS
+// handshakes. Sources may change the certificate at any time,
+// so certificates can be rotated without restarting the server.
+type CertificateSource interface {
+	GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error)
+}
+
+// CHECK_PERIOD is the minimal period used to check the
+// certificate sources for new certificates.
+const CHECK_PERIOD = 10 * time.Second
+
+type cachedCertificate struct {
+	lock      sync.Mutex
+	lastCheck time.Time
+	cert      *tls.Certificate
+}
+
+func (this *cachedCertificate) get(update func() (*tls.Certificate, error)) (*tls.Certificate, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.cert != nil && time.Now().Before(this.lastCheck.Add(CHECK_PERIOD)) {
+		return this.cert, nil
+	}
+	this.lastCheck = time.Now()
+	cert, err := update()
+	if err != nil {
+		if this.cert != nil {
+			logger.Warnf("cannot update server certificate (keeping old one): %s", err)
+			return this.cert, nil
+		}
+		return nil, err
+	}
+	if cert != nil {
+		this.cert = cert
+	}
+	return this.cert, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type fileCertificateSource struct {
+	cachedCertificate
+	certFile string
+	keyFile  string
+	modTime  time.Time
+}
+
+// NewFileCertificateSource provides the certificate stored in PEM files.
+// The files are reloaded whenever they are modified.
+func NewFileCertificateSource(certFile, keyFile string) (CertificateSource, error) {
+	this := &fileCertificateSource{certFile: certFile, keyFile: keyFile}
+	_, err := this.GetCertificate(nil)
+	if err != nil {
+		return nil, err
+	}
+	return this, nil
+}
+
+func (this *fileCertificateSource) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return this.get(this.load)
+}
+
+func (this *fileCertificateSource) load() (*tls.Certificate, error) {
+	modTime := time.Time{}
+	for _, f := range []string{this.certFile, this.keyFile} {
+		info, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	if this.cert != nil && !modTime.After(this.modTime) {
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(this.certFile, this.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load server certificate: %s", err)
+	}
+	if this.cert != nil {
+		logger.Infof("server certificate reloaded from %s", this.certFile)
+	}
+	this.modTime = modTime
+	return &cert, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// CertificateGetter is the read part of a certmgmt.CertificateAccess.
+type CertificateGetter interface {
+	Get(logger.LogContext) (cert.CertificateInfo, error)
+}
+
+type accessCertificateSource struct {
+	cachedCertificate
+	logger logger.LogContext
+	access CertificateGetter
+	data   []byte
+}
+
+// NewAccessCertificateSource provides the certificate of a certificate
+// access maintained by others. The access is queried periodically, therefore
+// externally renewed certificates are used without restarting the server.
+// Certificates maintained by a certmgmt.Renewer should be served by an
+// UpdatableCertificateSource subscribed to the renewer instead.
+func NewAccessCertificateSource(logger logger.LogContext, access CertificateGetter) (CertificateSource, error) {
+	this := &accessCertificateSource{logger: logger, access: access}
+	_, err := this.GetCertificate(nil)
+	if err != nil {
+		return nil, err
+	}
+	return this, nil
+}
+
+func (this *accessCertificateSource) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return this.get(this.load)
+}
+
+func (this *accessCertificateSource) load() (*tls.Certificate, error) {
+	info, err := this.access.Get(this.logger)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil || info.Cert() == nil || info.Key() == nil {
+		return nil, fmt.Errorf("no server certificate found")
+	}
+	data := append(append([]byte{}, info.Cert()...), info.Key()...)
+	if this.cert != nil && bytes.Equal(data, this.data) {
+		return nil, nil
+	}
+	cert, err := tls.X509KeyPair(info.Cert(), info.Key())
+	if err != nil {
+		return nil, fmt.Errorf("invalid server certificate: %s", err)
+	}
+	if this.cert != nil {
+		this.logger.Infof("server certificate updated")
+	}
+	this.data = data
+	return &cert, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// UpdatableCertificateSource serves the certificate passed by its Update
+// method from memory. Update matches certmgmt.CertificateHandler, so it can
+// be subscribed to a certmgmt.Renewer.
+type UpdatableCertificateSource interface {
+	CertificateSource
+	Update(info cert.CertificateInfo) error
+}
+
+type updatableCertificateSource struct {
+	lock sync.RWMutex
+	cert *tls.Certificate
+}
+
+func NewUpdatableCertificateSource() UpdatableCertificateSource {
+	return &updatableCertificateSource{}
+}
+
+func (this *updatableCertificateSource) Update(info cert.CertificateInfo) error {
+	if info == nil || info.Cert() == nil || info.Key() == nil {
+		return fmt.Errorf("no server certificate found")
+	}
+	cert, err := tls.X509KeyPair(info.Cert(), info.Key())
+	if err != nil {
+		return fmt.Errorf("invalid server certificate: %s", err)
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.cert != nil {
+		logger.Infof("server certificate updated")
+	}
+	this.cert = &cert
+	return nil
+}
+
+func (this *updatableCertificateSource) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if this.cert == nil {
+		return nil, fmt.Errorf("no server certificate")
+	}
+	return this.cert, nil
+}
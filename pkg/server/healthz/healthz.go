@@ -23,7 +23,7 @@ import (
 )
 
 func init() {
-	server.Register("/healthz", Healthz)
+	server.RegisterFor(server.HEALTH, "/healthz", Healthz)
 }
 
 // Healthz is a HTTP handler for the /healthz endpoint which responses with 200 OK status code
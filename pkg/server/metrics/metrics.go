@@ -0,0 +1,133 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+// Package metrics provides simple gauges served in the
+// prometheus text format on the /metrics endpoint.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+)
+
+func init() {
+	server.RegisterFor(server.METRICS, "/metrics", Metrics)
+}
+
+type GaugeVec struct {
+	lock   sync.Mutex
+	name   string
+	help   string
+	labels []string
+	values map[string]float64
+}
+
+var (
+	lock   sync.Mutex
+	gauges = map[string]*GaugeVec{}
+)
+
+// NewGaugeVec creates and registers a gauge with the given label names.
+// Registering a gauge with the same name twice returns the
+// already registered one.
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if g := gauges[name]; g != nil {
+		if strings.Join(g.labels, ",") != strings.Join(labels, ",") {
+			panic(fmt.Sprintf("gauge %q already registered with labels %v", name, g.labels))
+		}
+		return g
+	}
+	g := &GaugeVec{name: name, help: help, labels: labels, values: map[string]float64{}}
+	gauges[name] = g
+	return g
+}
+
+func (this *GaugeVec) key(values []string) string {
+	if len(values) != len(this.labels) {
+		panic(fmt.Sprintf("gauge %q requires %d label values", this.name, len(this.labels)))
+	}
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = fmt.Sprintf("%s=%s", this.labels[i], strconv.Quote(v))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (this *GaugeVec) Set(value float64, labelValues ...string) {
+	key := this.key(labelValues)
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.values[key] = value
+}
+
+func (this *GaugeVec) Delete(labelValues ...string) {
+	key := this.key(labelValues)
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.values, key)
+}
+
+func (this *GaugeVec) write(buf *bytes.Buffer) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	fmt.Fprintf(buf, "# HELP %s %s\n", this.name, this.help)
+	fmt.Fprintf(buf, "# TYPE %s gauge\n", this.name)
+	keys := []string{}
+	for k := range this.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := strconv.FormatFloat(this.values[k], 'g', -1, 64)
+		if k == "" {
+			fmt.Fprintf(buf, "%s %s\n", this.name, v)
+		} else {
+			fmt.Fprintf(buf, "%s{%s} %s\n", this.name, k, v)
+		}
+	}
+}
+
+// Metrics is the HTTP handler for the /metrics endpoint.
+func Metrics(w http.ResponseWriter, r *http.Request) {
+	lock.Lock()
+	names := []string{}
+	for n := range gauges {
+		names = append(names, n)
+	}
+	lock.Unlock()
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	for _, n := range names {
+		lock.Lock()
+		g := gauges[n]
+		lock.Unlock()
+		g.write(buf)
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
@@ -18,6 +18,8 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"github.com/gardener/controller-manager-library/pkg/ctxutil"
 	"net/http"
@@ -26,37 +28,143 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/logger"
 )
 
+// Kind describes the category of an endpoint. Every kind
+// of endpoints can be served by a separate listener.
+type Kind string
+
+const HEALTH = Kind("health")
+const METRICS = Kind("metrics")
+const ADMIN = Kind("admin")
+
+// WEBHOOK endpoints are called by the kube-apiserver. They are not part
+// of AllKinds, and are only served by listeners explicitly configured
+// for this kind.
+const WEBHOOK = Kind("webhook")
+
+// AllKinds lists the endpoint kinds in the order used to
+// dispatch requests for a listener serving multiple kinds.
+var AllKinds = []Kind{HEALTH, METRICS, ADMIN}
+
+// admin endpoints are kept on the default mux, to serve
+// handlers registered directly at the http package, also.
+var muxes = map[Kind]*http.ServeMux{
+	HEALTH:  http.NewServeMux(),
+	METRICS: http.NewServeMux(),
+	ADMIN:   http.DefaultServeMux,
+	WEBHOOK: http.NewServeMux(),
+}
+
 func Register(pattern string, handler func(http.ResponseWriter, *http.Request)) {
-	logger.Infof("adding %s endpoint", pattern)
-	http.HandleFunc(pattern, handler)
+	RegisterFor(ADMIN, pattern, handler)
 }
 
 func RegisterHandler(pattern string, handler http.Handler) {
-	logger.Infof("adding %s endpoint", pattern)
-	http.Handle(pattern, handler)
+	RegisterHandlerFor(ADMIN, pattern, handler)
+}
+
+func RegisterFor(kind Kind, pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	RegisterHandlerFor(kind, pattern, http.HandlerFunc(handler))
+}
+
+func RegisterHandlerFor(kind Kind, pattern string, handler http.Handler) {
+	mux := muxes[kind]
+	if mux == nil {
+		panic(fmt.Sprintf("invalid endpoint kind %q", kind))
+	}
+	logger.Infof("adding %s endpoint %s", kind, pattern)
+	mux.Handle(pattern, handler)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Config describes a listener of the HTTP server.
+type Config struct {
+	BindAddress string
+	Port        int
+	// Kinds are the endpoint kinds served by the listener (default: all)
+	Kinds []Kind
+	// Certificates enables HTTPS, if set
+	Certificates CertificateSource
+	// ClientCAs enables the verification of client certificates
+	ClientCAs *x509.CertPool
+	// Authenticator is used for all endpoints, but the health endpoints.
+	Authenticator Authenticator
 }
 
 // Serve starts a HTTP server.
 func Serve(ctx context.Context, bindAddress string, port int) {
-	logger.Info("starting http server")
+	ServeConfig(ctx, "http", Config{BindAddress: bindAddress, Port: port})
+}
+
+// ServeConfig starts a HTTP(S) server for the given listener config.
+// If the server fails, the context is cancelled.
+func ServeConfig(ctx context.Context, name string, cfg Config) {
+	logger.Infof("starting %s server", name)
 
-	listenAddress := fmt.Sprintf("%s:%d", bindAddress, port)
-	server := &http.Server{Addr: listenAddress, Handler: nil}
+	kinds := cfg.Kinds
+	if len(kinds) == 0 {
+		kinds = AllKinds
+	}
+	listenAddress := fmt.Sprintf("%s:%d", cfg.BindAddress, cfg.Port)
+	server := &http.Server{Addr: listenAddress, Handler: &handler{kinds: kinds, auth: cfg.Authenticator}}
+
+	if cfg.Certificates != nil {
+		server.TLSConfig = &tls.Config{GetCertificate: cfg.Certificates.GetCertificate}
+		if cfg.ClientCAs != nil {
+			server.TLSConfig.ClientCAs = cfg.ClientCAs
+			server.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
 
 	go func() {
 		<-ctx.Done()
-		logger.Infof("shutting down server with timeout")
-		ctx, _ := context.WithTimeout(context.Background(), 5*time.Second)
+		logger.Infof("shutting down %s server with timeout", name)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
 		server.Shutdown(ctx)
 	}()
 
 	go func() {
-		logger.Infof("HTTP server started (serving on %s)", listenAddress)
-		err := server.ListenAndServe()
-		if err != nil {
-			logger.Errorf("cannot start http server: %s", err)
+		var err error
+		if server.TLSConfig != nil {
+			logger.Infof("HTTPS %s server started (serving %v on %s)", name, kinds, listenAddress)
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			logger.Infof("HTTP %s server started (serving %v on %s)", name, kinds, listenAddress)
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logger.Errorf("cannot start %s server: %s", name, err)
 		}
-		logger.Infof("HTTP server stopped")
+		logger.Infof("%s server stopped", name)
 		ctxutil.Cancel(ctx)
 	}()
 }
+
+type handler struct {
+	kinds []Kind
+	auth  Authenticator
+}
+
+func (this *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	for _, kind := range this.kinds {
+		h, pattern := muxes[kind].Handler(r)
+		if pattern == "" {
+			continue
+		}
+		if kind != HEALTH && this.auth != nil {
+			user, ok, err := this.auth.Authenticate(r)
+			if err != nil {
+				logger.Warnf("authentication for %s failed: %s", r.URL.Path, err)
+			}
+			if !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			logger.Debugf("request %s authenticated for %s", r.URL.Path, user)
+		}
+		h.ServeHTTP(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}